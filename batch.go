@@ -0,0 +1,90 @@
+package goplaces
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const defaultBatchConcurrency = 4
+
+// BatchOptions controls bulk calls such as DirectionsBatch.
+type BatchOptions struct {
+	// StopOnError cancels outstanding work and returns the first failure.
+	// By default every item runs and failures are reported per item.
+	StopOnError bool
+	// Concurrency caps in-flight requests (default 4).
+	Concurrency int
+	// Progress, when set, is called after each item with the number done so
+	// far. Calls are serialized but may come from any goroutine.
+	Progress func(done, total int)
+}
+
+// DirectionsBatchResult is one DirectionsBatch item, in request order.
+type DirectionsBatchResult struct {
+	Request  DirectionsRequest  `json:"request"`
+	Response DirectionsResponse `json:"response"`
+	Err      error              `json:"-"`
+}
+
+// DirectionsBatch runs many directions requests, e.g. one origin to many
+// destinations. Without StopOnError it returns a nil error and per-item
+// errors in Err; with StopOnError it returns the first error, and items that
+// never ran keep a zero Response and nil Err.
+func (c *Client) DirectionsBatch(ctx context.Context, reqs []DirectionsRequest, opts BatchOptions) ([]DirectionsBatchResult, error) {
+	results := make([]DirectionsBatchResult, len(reqs))
+	for i, req := range reqs {
+		results[i].Request = req
+	}
+	err := runBatch(ctx, len(reqs), opts, func(ctx context.Context, i int) error {
+		response, err := c.Directions(ctx, reqs[i])
+		results[i].Response = response
+		results[i].Err = err
+		return err
+	})
+	return results, err
+}
+
+// runBatch calls fn for items 0..n-1 with bounded concurrency. In stop mode
+// the first error cancels the shared context and no further items start.
+func runBatch(ctx context.Context, n int, opts BatchOptions, fn func(ctx context.Context, i int) error) error {
+	// Bulk work yields to interactive calls on a shared TokenBucket.
+	ctx, cancel := context.WithCancel(withDefaultPriority(ctx, PriorityBatch))
+	defer cancel()
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	var progressMu sync.Mutex
+	done := 0
+	for i := range n {
+		slots <- struct{}{}
+		if opts.StopOnError && ctx.Err() != nil {
+			<-slots
+			break
+		}
+		wg.Go(func() {
+			defer func() { <-slots }()
+			err := fn(ctx, i)
+			if opts.Progress != nil {
+				progressMu.Lock()
+				done++
+				opts.Progress(done, n)
+				progressMu.Unlock()
+			}
+			if err != nil && opts.StopOnError {
+				once.Do(func() {
+					firstErr = fmt.Errorf("goplaces: batch item %d: %w", i, err)
+					cancel()
+				})
+			}
+		})
+	}
+	wg.Wait()
+	return firstErr
+}
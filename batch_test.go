@@ -0,0 +1,95 @@
+package goplaces
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func newBatchServer(t *testing.T, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Query().Get("destination") == "bad" {
+			_, _ = w.Write([]byte(`{"status": "NOT_FOUND", "routes": []}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{"end_address": "` + r.URL.Query().Get("destination") + `", "steps": []}]}]}`))
+	}))
+}
+
+func batchRequests(destinations ...string) []DirectionsRequest {
+	reqs := make([]DirectionsRequest, 0, len(destinations))
+	for _, to := range destinations {
+		reqs = append(reqs, DirectionsRequest{From: "A", To: to, Mode: "walk"})
+	}
+	return reqs
+}
+
+func TestDirectionsBatchCollectsErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := newBatchServer(t, &calls)
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	results, err := client.DirectionsBatch(context.Background(), batchRequests("B", "bad", "C"), BatchOptions{})
+	if err != nil {
+		t.Fatalf("collect mode should not return an error, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("expected 3 requests, got %d", calls.Load())
+	}
+	if results[0].Err != nil || results[0].Response.EndAddress != "B" {
+		t.Fatalf("unexpected first result: %#v", results[0])
+	}
+	if results[1].Err == nil || results[1].Request.To != "bad" {
+		t.Fatalf("expected per-item error for bad destination: %#v", results[1])
+	}
+	if results[2].Err != nil || results[2].Response.EndAddress != "C" {
+		t.Fatalf("unexpected last result: %#v", results[2])
+	}
+}
+
+func TestDirectionsBatchStopOnError(t *testing.T) {
+	var calls atomic.Int32
+	server := newBatchServer(t, &calls)
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	results, err := client.DirectionsBatch(context.Background(), batchRequests("B", "bad", "C", "D"), BatchOptions{
+		StopOnError: true,
+		Concurrency: 1,
+	})
+	if err == nil {
+		t.Fatalf("expected stop-on-error failure")
+	}
+	if results[1].Err == nil || !errors.Is(err, results[1].Err) {
+		t.Fatalf("expected returned error to wrap item 1: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("expected work to stop after the failure, got %d requests", calls.Load())
+	}
+	if results[2].Err != nil || results[2].Response.EndAddress != "" {
+		t.Fatalf("expected unstarted item to stay empty: %#v", results[2])
+	}
+}
+
+func TestRunBatchCancelsInFlight(t *testing.T) {
+	boom := errors.New("boom")
+	started := make(chan struct{})
+	err := runBatch(context.Background(), 2, BatchOptions{StopOnError: true, Concurrency: 2}, func(ctx context.Context, i int) error {
+		if i == 0 {
+			<-started
+			return boom
+		}
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected first error to win, got %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package goplaces
+
+import (
+	"container/list"
+	"encoding/json"
+	"net/url"
+	"sync"
+)
+
+// Cache stores raw Directions and Geocoding responses keyed by request URL.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte)
+}
+
+// MemoryCache is an in-process Cache that evicts the least recently used
+// entry once it holds maxEntries.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List
+	entries    map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key   string
+	value []byte
+}
+
+// NewMemoryCache returns an empty MemoryCache. maxEntries below 1 is treated as 1.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: max(maxEntries, 1),
+		order:      list.New(),
+		entries:    map[string]*list.Element{},
+	}
+}
+
+// Get returns the cached value and marks it most recently used.
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	m.order.MoveToFront(element)
+	return element.Value.(*memoryCacheEntry).value, true
+}
+
+// Set stores value under key, evicting the oldest entry when full.
+func (m *MemoryCache) Set(key string, value []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if element, ok := m.entries[key]; ok {
+		element.Value.(*memoryCacheEntry).value = value
+		m.order.MoveToFront(element)
+		return
+	}
+	m.entries[key] = m.order.PushFront(&memoryCacheEntry{key: key, value: value})
+	for m.order.Len() > m.maxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
+
+// Len reports the number of cached entries.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// cacheKey is the endpoint without the API key, with parameters in sorted
+// order, so rotating keys shares entries.
+func cacheKey(endpoint string) string {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return endpoint
+	}
+	query := parsed.Query()
+	query.Del("key")
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
+
+// timeDependentParams make Google's answer a live ETA that goes stale within
+// minutes, so requests carrying any of them bypass the cache.
+var timeDependentParams = []string{"departure_time", "arrival_time", "traffic_model"}
+
+// cacheableRequest reports whether key (see cacheKey) names a static query.
+func cacheableRequest(key string) bool {
+	parsed, err := url.Parse(key)
+	if err != nil {
+		return false
+	}
+	query := parsed.Query()
+	for _, name := range timeDependentParams {
+		if query.Has(name) {
+			return false
+		}
+	}
+	return true
+}
+
+// cacheableResponse keeps quota and auth failures out of the cache; only
+// answers about the route itself (OK, ZERO_RESULTS, NOT_FOUND) are reusable.
+func cacheableResponse(payload []byte) bool {
+	var envelope struct {
+		Status string `json:"status"`
+	}
+	if json.Unmarshal(payload, &envelope) != nil {
+		return false
+	}
+	switch envelope.Status {
+	case "OK", "ZERO_RESULTS", "NOT_FOUND":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package goplaces
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDirectionsCacheSkipsRepeatRequests(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Query().Get("destination") == "Nowhere" {
+			_, _ = w.Write([]byte(`{"status": "OVER_QUERY_LIMIT", "routes": []}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{"distance": {"value": 1}, "duration": {"value": 1}}]}]}`))
+	}))
+	defer server.Close()
+
+	cache := NewMemoryCache(10)
+	first := NewClient(Options{APIKey: "key-1", DirectionsBaseURL: server.URL, Cache: cache})
+	rotated := NewClient(Options{APIKey: "key-2", DirectionsBaseURL: server.URL, Cache: cache})
+	req := DirectionsRequest{From: "A", To: "B"}
+	for _, client := range []*Client{first, first, rotated} {
+		if _, err := client.Directions(context.Background(), req); err != nil {
+			t.Fatalf("directions: %v", err)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected 1 upstream call, got %d", calls.Load())
+	}
+
+	for range 2 {
+		if _, err := first.Directions(context.Background(), DirectionsRequest{From: "A", To: "Nowhere"}); err == nil {
+			t.Fatalf("expected quota error")
+		}
+	}
+	if calls.Load() != 3 || cache.Len() != 1 {
+		t.Fatalf("quota errors must not be cached: %d calls, %d entries", calls.Load(), cache.Len())
+	}
+}
+
+func TestDirectionsCacheSkipsTimeDependentRequests(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{"distance": {"value": 1}, "duration": {"value": 1}}]}]}`))
+	}))
+	defer server.Close()
+
+	cache := NewMemoryCache(10)
+	client := NewClient(Options{APIKey: "key", DirectionsBaseURL: server.URL, Cache: cache})
+	departure := time.Date(2030, 1, 2, 8, 0, 0, 0, time.UTC)
+	requests := []DirectionsRequest{
+		{From: "A", To: "B", DepartureTime: &departure},
+		{From: "A", To: "B", Mode: "transit", ArrivalTime: &departure},
+		{From: "A", To: "B", Mode: "drive", DepartureTime: &departure, TrafficModel: "pessimistic"},
+	}
+	for _, req := range requests {
+		for range 2 {
+			if _, err := client.Directions(context.Background(), req); err != nil {
+				t.Fatalf("directions: %v", err)
+			}
+		}
+	}
+	if calls.Load() != 6 || cache.Len() != 0 {
+		t.Fatalf("time-dependent requests must bypass the cache: %d calls, %d entries", calls.Load(), cache.Len())
+	}
+}
+
+func TestCacheableRequest(t *testing.T) {
+	if !cacheableRequest("https://example.com/json?origin=A&destination=B&mode=driving") {
+		t.Fatalf("static request should be cacheable")
+	}
+	for _, param := range timeDependentParams {
+		if cacheableRequest("https://example.com/json?origin=A&destination=B&" + param + "=1") {
+			t.Fatalf("%s request should not be cacheable", param)
+		}
+	}
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewMemoryCache(2)
+	cache.Set("a", []byte("1"))
+	cache.Set("b", []byte("2"))
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatalf("expected a")
+	}
+	cache.Set("c", []byte("3"))
+	if _, ok := cache.Get("b"); ok {
+		t.Fatalf("b should have been evicted")
+	}
+	cache.Set("a", []byte("4"))
+	if value, ok := cache.Get("a"); !ok || string(value) != "4" || cache.Len() != 2 {
+		t.Fatalf("unexpected a: %q %v (len %d)", value, ok, cache.Len())
+	}
+}
+
+func TestCacheKeyIgnoresAPIKey(t *testing.T) {
+	one := cacheKey("https://example.com/json?origin=A&key=secret&destination=B")
+	two := cacheKey("https://example.com/json?key=other&destination=B&origin=A")
+	if one != two || strings.Contains(one, "secret") {
+		t.Fatalf("unexpected keys: %q %q", one, two)
+	}
+}
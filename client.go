@@ -10,30 +10,131 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
+	"sync"
 	"time"
 )
 
 // DefaultBaseURL is the default endpoint for the Places API (New).
 const DefaultBaseURL = "https://places.googleapis.com/v1"
 
+// DefaultMaxURLLength caps GET request URLs; Google rejects much longer ones with 414.
+const DefaultMaxURLLength = 8192
+
+// DefaultMaxResponseBytes caps how much of a response body is read (1 MiB).
+const DefaultMaxResponseBytes = 1 << 20
+
 // Client wraps access to the Google Places API.
 type Client struct {
-	apiKey            string
-	baseURL           string
-	routesBaseURL     string
-	directionsBaseURL string
-	httpClient        *http.Client
+	apiKey        string
+	baseURL       string
+	routesBaseURL string
+	// Legacy GET endpoints are parsed once; see buildDirectionsURL.
+	directionsEndpoint queryEndpoint
+	geocodeEndpoint    queryEndpoint
+	httpClient         *http.Client
+	maxURLLength       int
+	autoRegion         bool
+	autoUnits          bool
+	warningsAreErrors  bool
+
+	clock   func() time.Time
+	limiter RateLimiter
+
+	maxRetries     int
+	retryBaseDelay time.Duration
+	cache          Cache
+	flights        *flightGroup
+	userAgent      string
+
+	countryMu    sync.Mutex
+	countryCache map[string]countryCacheEntry
+
+	distanceMatrixEndpoint queryEndpoint
+
+	onRequest  func(*http.Request)
+	onResponse func(*http.Response, time.Duration)
+	debug      *debugRing
+
+	maxResponseBytes int64
 }
 
+// apiKeyEnvVars are read, in order, when Options.APIKey is empty.
+var apiKeyEnvVars = []string{"GOOGLE_PLACES_API_KEY", "GOOGLE_MAPS_API_KEY"}
+
 // Options configures the Places client.
 type Options struct {
+	// APIKey falls back to $GOOGLE_PLACES_API_KEY, then $GOOGLE_MAPS_API_KEY.
 	APIKey            string
 	BaseURL           string
 	RoutesBaseURL     string
 	DirectionsBaseURL string
-	HTTPClient        *http.Client
-	Timeout           time.Duration
+	GeocodeBaseURL    string
+	// HTTPClient sends every request, so its Transport is the place for
+	// RoundTripper middleware (logging, caching, auth). Nil means a fresh
+	// client with Timeout; Timeout is ignored when it is set.
+	HTTPClient *http.Client
+	// Timeout bounds each HTTP attempt of the default client (default 10s),
+	// so a hung connection fails even without a context deadline; the error
+	// matches context.DeadlineExceeded. A shorter context deadline still
+	// takes precedence.
+	Timeout time.Duration
+	// MaxURLLength caps GET request URLs (default DefaultMaxURLLength).
+	MaxURLLength int
+	// AutoRegion derives the Directions region from origin coordinates via the
+	// Geocoding API when a request has no explicit region.
+	AutoRegion bool
+	// AutoUnits picks imperial units for destinations in mile-signed countries
+	// (US, UK, Liberia, Myanmar) and metric elsewhere when a request has no
+	// explicit units. Uses the Geocoding API.
+	AutoUnits bool
+	// WarningsAreErrors rejects routes that carry warnings with a RouteWarningError.
+	WarningsAreErrors bool
+	// Clock replaces time.Now for the default transit departure time and the
+	// expiry of AutoRegion/AutoUnits country lookups, so tests can freeze
+	// time. Retry backoff and TokenBucket use real timers.
+	Clock func() time.Time
+	// RateLimiter, when set, is waited on before every HTTP request (Places,
+	// Routes, Directions, Geocoding, photos, and each retry); an error from
+	// Wait aborts the call. See NewRateLimiter and WithPriority.
+	RateLimiter RateLimiter
+	// MaxRetries retries 429/5xx responses and legacy OVER_QUERY_LIMIT
+	// statuses up to this many times with jittered exponential backoff
+	// starting at RetryBaseDelay (default DefaultRetryBaseDelay). Other
+	// errors fail at once. Zero disables retries.
+	MaxRetries     int
+	RetryBaseDelay time.Duration
+	// Cache, when set, serves repeated Directions and Geocoding requests
+	// without a network call. Keys are the request URL minus the API key;
+	// only OK, ZERO_RESULTS, and NOT_FOUND responses are stored. Requests
+	// with a departure time, arrival time, or traffic model skip the cache,
+	// since their ETAs go stale within minutes. See NewMemoryCache.
+	Cache Cache
+	// CoalesceRequests makes identical concurrent Directions and Geocoding
+	// requests (same URL minus the API key) share one HTTP call.
+	CoalesceRequests bool
+	// UserAgent is sent on every request (default DefaultUserAgent).
+	UserAgent string
+	// DistanceMatrixBaseURL overrides the Distance Matrix API endpoint.
+	DistanceMatrixBaseURL string
+	// OnRequest is called before every HTTP attempt (retries and photo
+	// redirects included) with a copy of the request whose API key, in the
+	// URL or the X-Goog-Api-Key header, reads REDACTED.
+	OnRequest func(*http.Request)
+	// OnResponse is called after every attempt with the response (nil when
+	// the request failed) and its latency. It must not read or close the
+	// body. Hooks run on the calling goroutine and should be quick.
+	OnResponse func(*http.Response, time.Duration)
+	// Debug records every HTTP exchange (method, redacted URL, status, and
+	// the start of the body) for Client.DebugLog. Response bodies pass
+	// through unchanged; a supplied HTTPClient is copied, not modified.
+	Debug bool
+	// MaxResponseBytes caps each API response body (default
+	// DefaultMaxResponseBytes); a larger body fails with ErrResponseTooLarge
+	// instead of being cut short. Raise it for transit routes with many
+	// alternatives.
+	MaxResponseBytes int64
 }
 
 // NewClient builds a client with sane defaults.
@@ -50,6 +151,22 @@ func NewClient(opts Options) *Client {
 	if directionsBaseURL == "" {
 		directionsBaseURL = defaultDirectionsBaseURL
 	}
+	geocodeBaseURL := strings.TrimRight(opts.GeocodeBaseURL, "/")
+	if geocodeBaseURL == "" {
+		geocodeBaseURL = defaultGeocodeBaseURL
+	}
+	distanceMatrixBaseURL := strings.TrimRight(opts.DistanceMatrixBaseURL, "/")
+	if distanceMatrixBaseURL == "" {
+		distanceMatrixBaseURL = defaultDistanceMatrixBaseURL
+	}
+
+	apiKey := strings.TrimSpace(opts.APIKey)
+	for _, name := range apiKeyEnvVars {
+		if apiKey != "" {
+			break
+		}
+		apiKey = strings.TrimSpace(os.Getenv(name))
+	}
 
 	client := opts.HTTPClient
 	if client == nil {
@@ -59,14 +176,123 @@ func NewClient(opts Options) *Client {
 		}
 		client = &http.Client{Timeout: timeout}
 	}
+	var debug *debugRing
+	if opts.Debug {
+		debug = &debugRing{}
+		client = withDebugTransport(client, debug)
+	}
+
+	maxURLLength := opts.MaxURLLength
+	if maxURLLength <= 0 {
+		maxURLLength = DefaultMaxURLLength
+	}
+
+	clock := opts.Clock
+	if clock == nil {
+		clock = time.Now
+	}
+
+	retryBaseDelay := opts.RetryBaseDelay
+	if retryBaseDelay <= 0 {
+		retryBaseDelay = DefaultRetryBaseDelay
+	}
+
+	maxResponseBytes := opts.MaxResponseBytes
+	if maxResponseBytes <= 0 {
+		maxResponseBytes = DefaultMaxResponseBytes
+	}
+
+	userAgent := strings.TrimSpace(opts.UserAgent)
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+
+	var flights *flightGroup
+	if opts.CoalesceRequests {
+		flights = newFlightGroup()
+	}
 
 	return &Client{
-		apiKey:            opts.APIKey,
-		baseURL:           baseURL,
-		routesBaseURL:     routesBaseURL,
-		directionsBaseURL: directionsBaseURL,
-		httpClient:        client,
+		apiKey:             apiKey,
+		baseURL:            baseURL,
+		routesBaseURL:      routesBaseURL,
+		directionsEndpoint: newQueryEndpoint(directionsBaseURL),
+		geocodeEndpoint:    newQueryEndpoint(geocodeBaseURL),
+		httpClient:         client,
+		maxURLLength:       maxURLLength,
+		autoRegion:         opts.AutoRegion,
+		autoUnits:          opts.AutoUnits,
+		warningsAreErrors:  opts.WarningsAreErrors,
+		clock:              clock,
+		limiter:            opts.RateLimiter,
+		countryCache:       map[string]countryCacheEntry{},
+		maxRetries:         max(opts.MaxRetries, 0),
+		retryBaseDelay:     retryBaseDelay,
+		cache:              opts.Cache,
+		flights:            flights,
+		userAgent:          userAgent,
+
+		distanceMatrixEndpoint: newQueryEndpoint(distanceMatrixBaseURL),
+
+		onRequest:  opts.OnRequest,
+		onResponse: opts.OnResponse,
+		debug:      debug,
+
+		maxResponseBytes: maxResponseBytes,
+	}
+}
+
+// NewClientWithError is NewClient with the configured base URLs checked up
+// front, so a typo fails at construction rather than on the first request.
+// Empty URLs use the defaults and are not an error.
+func NewClientWithError(opts Options) (*Client, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
 	}
+	return NewClient(opts), nil
+}
+
+// Validate reports the first base URL that is not an absolute http(s) URL.
+// It has no side effects, so it can be called any number of times.
+func (o Options) Validate() error {
+	urls := []struct {
+		field string
+		value string
+	}{
+		{"base_url", o.BaseURL},
+		{"routes_base_url", o.RoutesBaseURL},
+		{"directions_base_url", o.DirectionsBaseURL},
+		{"geocode_base_url", o.GeocodeBaseURL},
+		{"distance_matrix_base_url", o.DistanceMatrixBaseURL},
+	}
+	for _, entry := range urls {
+		if err := validateBaseURL(entry.field, entry.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateBaseURL(field string, value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return ValidationError{Field: field, Message: fmt.Sprintf("invalid url %q", redactKey(value))}
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return ValidationError{Field: field, Message: fmt.Sprintf("must be an http or https url, got %q", redactKey(value))}
+	}
+	if parsed.Host == "" {
+		return ValidationError{Field: field, Message: fmt.Sprintf("missing host in %q", redactKey(value))}
+	}
+	return nil
+}
+
+func (c *Client) now() time.Time {
+	return c.clock()
 }
 
 func (c *Client) doRequest(
@@ -80,13 +306,64 @@ func (c *Client) doRequest(
 		return nil, ErrMissingAPIKey
 	}
 
-	var reader io.Reader
+	var encoded []byte
 	if body != nil {
 		payload, err := json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("goplaces: encode request: %w", err)
 		}
-		reader = bytes.NewReader(payload)
+		encoded = payload
+	}
+
+	return c.withRetries(ctx, func() ([]byte, error) {
+		return c.send(ctx, method, endpoint, encoded, fieldMask)
+	})
+}
+
+// do sends one HTTP attempt, reporting it to the OnRequest and OnResponse
+// hooks. Latency uses the wall clock, not Options.Clock. Transport errors
+// quote the URL, so the key is redacted from them.
+func (c *Client) do(httpClient *http.Client, request *http.Request) (*http.Response, error) {
+	if c.onRequest != nil {
+		c.onRequest(redactedRequest(request))
+	}
+	start := time.Now()
+	response, err := httpClient.Do(request)
+	if c.onResponse != nil {
+		c.onResponse(response, time.Since(start))
+	}
+	return response, redactURLError(err)
+}
+
+// redactedRequest copies request for hooks with the API key masked, like
+// cacheKey strips it. The copy gets its own body when one can be replayed.
+func redactedRequest(request *http.Request) *http.Request {
+	clone := request.Clone(request.Context())
+	if request.GetBody != nil {
+		if body, err := request.GetBody(); err == nil {
+			clone.Body = body
+		}
+	}
+	query := clone.URL.Query()
+	if query.Has("key") {
+		query.Set("key", redactedKey)
+		clone.URL.RawQuery = query.Encode()
+	}
+	if clone.Header.Get("X-Goog-Api-Key") != "" {
+		clone.Header.Set("X-Goog-Api-Key", redactedKey)
+	}
+	return clone
+}
+
+// send performs one Places/Routes API call after waiting on the rate
+// limiter; doRequest retries it.
+func (c *Client) send(ctx context.Context, method string, endpoint string, body []byte, fieldMask string) ([]byte, error) {
+	if err := c.waitForLimiter(ctx); err != nil {
+		return nil, err
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
 	}
 
 	request, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
@@ -95,13 +372,14 @@ func (c *Client) doRequest(
 	}
 
 	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", c.userAgent)
 	request.Header.Set("X-Goog-Api-Key", c.apiKey)
 	// Field masks trim API payloads and keep responses fast/cheap.
 	if strings.TrimSpace(fieldMask) != "" {
 		request.Header.Set("X-Goog-FieldMask", fieldMask)
 	}
 
-	response, err := c.httpClient.Do(request)
+	response, err := c.do(c.httpClient, request)
 	if err != nil {
 		return nil, fmt.Errorf("goplaces: request failed: %w", err)
 	}
@@ -110,14 +388,13 @@ func (c *Client) doRequest(
 	}()
 
 	// Hard-cap payload size to avoid runaway error bodies.
-	payload, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	payload, err := readLimited(response.Body, c.maxResponseBytes)
 	if err != nil {
-		return nil, fmt.Errorf("goplaces: read response: %w", err)
+		return nil, err
 	}
 
 	if response.StatusCode >= http.StatusBadRequest {
-		apiErr := &APIError{StatusCode: response.StatusCode, Body: strings.TrimSpace(string(payload))}
-		return nil, apiErr
+		return nil, newAPIError(response.StatusCode, payload)
 	}
 
 	if len(payload) == 0 {
@@ -127,6 +404,19 @@ func (c *Client) doRequest(
 	return payload, nil
 }
 
+// readLimited reads at most limit bytes and fails with ErrResponseTooLarge
+// when body holds more, so a cut-off payload never reaches the decoder.
+func readLimited(body io.Reader, limit int64) ([]byte, error) {
+	payload, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("goplaces: read response: %w", err)
+	}
+	if int64(len(payload)) > limit {
+		return nil, fmt.Errorf("%w: more than %d bytes; raise Options.MaxResponseBytes", ErrResponseTooLarge, limit)
+	}
+	return payload, nil
+}
+
 func (c *Client) buildURL(path string, query map[string]string) (string, error) {
 	endpoint := c.baseURL + path
 	if len(query) == 0 {
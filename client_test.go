@@ -8,7 +8,9 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -119,6 +121,43 @@ func TestSearchSuccess(t *testing.T) {
 	}
 }
 
+func TestSearchEVChargeOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Goog-FieldMask") != searchFieldMask+",places.evChargeOptions" {
+			t.Fatalf("unexpected field mask: %s", r.Header.Get("X-Goog-FieldMask"))
+		}
+		_, _ = w.Write([]byte(`{"places": [{"id": "ev", "evChargeOptions": {
+			"connectorCount": 6,
+			"connectorAggregation": [
+				{"type": "EV_CONNECTOR_TYPE_TESLA", "maxChargeRateKw": 250, "count": 4, "availableCount": 1, "outOfServiceCount": 1, "availabilityLastUpdateTime": "2026-01-01T00:00:00Z"},
+				{"type": "EV_CONNECTOR_TYPE_J1772", "maxChargeRateKw": 7.2, "count": 2}
+			]
+		}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	response, err := client.Search(context.Background(), SearchRequest{Query: "charger", IncludeEVChargeOptions: true})
+	if err != nil {
+		t.Fatalf("search error: %v", err)
+	}
+	options := response.Results[0].EVChargeOptions
+	if options == nil || options.ConnectorCount != 6 || len(options.Connectors) != 2 {
+		t.Fatalf("unexpected ev options: %#v", options)
+	}
+	if options.Connectors[0].Type != "TESLA" || options.Connectors[0].MaxChargeRateKW != 250 {
+		t.Fatalf("unexpected connector: %#v", options.Connectors[0])
+	}
+	if available, known := options.AvailableConnectors(); available != 1 || !known {
+		t.Fatalf("unexpected availability: %d %v", available, known)
+	}
+
+	var missing *EVChargeOptions
+	if _, known := missing.AvailableConnectors(); known {
+		t.Fatalf("expected unknown availability for nil options")
+	}
+}
+
 func TestSearchHTTPError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
@@ -289,6 +328,8 @@ func TestNearbySearchSuccess(t *testing.T) {
       "rating": 4.7,
       "priceLevel": "PRICE_LEVEL_MODERATE",
       "types": ["cafe"],
+      "primaryType": "cafe",
+      "primaryTypeDisplayName": {"text": "Café", "languageCode": "en"},
       "currentOpeningHours": {"openNow": true}
     }
   ],
@@ -315,6 +356,9 @@ func TestNearbySearchSuccess(t *testing.T) {
 	if response.NextPageToken != "next" {
 		t.Fatalf("unexpected token: %s", response.NextPageToken)
 	}
+	if response.Results[0].PrimaryType != "cafe" || response.Results[0].PrimaryTypeName != "Café" {
+		t.Fatalf("unexpected primary type: %#v", response.Results[0])
+	}
 
 	if gotRequest["maxResultCount"].(float64) != 5 {
 		t.Fatalf("unexpected maxResultCount: %#v", gotRequest["maxResultCount"])
@@ -363,6 +407,77 @@ func TestPhotoMediaSuccess(t *testing.T) {
 	}
 }
 
+func TestFetchPhotoFollowsRedirect(t *testing.T) {
+	cdnHits := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/places/place-1/photos/photo-1/media", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Goog-Api-Key") != "test-key" || r.URL.Query().Get("maxWidthPx") != "400" {
+			t.Fatalf("unexpected media request: %s", r.URL)
+		}
+		http.Redirect(w, r, "/cdn/photo.jpg", http.StatusFound)
+	})
+	mux.HandleFunc("/cdn/photo.jpg", func(w http.ResponseWriter, r *http.Request) {
+		cdnHits++
+		if r.Header.Get("X-Goog-Api-Key") != "" {
+			t.Fatalf("api key leaked to the image host")
+		}
+		w.Header().Set("Content-Type", "image/jpeg")
+		_, _ = w.Write([]byte("jpeg-bytes"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// A client that refuses redirects must not break FetchPhoto.
+	strict := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return errors.New("redirects disabled")
+	}}
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL + "/v1", HTTPClient: strict})
+	req := PhotoMediaRequest{Name: "places/place-1/photos/photo-1", MaxWidthPx: 400}
+
+	photo, err := client.FetchPhoto(context.Background(), req, PhotoOptions{})
+	if err != nil {
+		t.Fatalf("fetch photo error: %v", err)
+	}
+	if string(photo.Data) != "jpeg-bytes" || photo.ContentType != "image/jpeg" || photo.URL != server.URL+"/cdn/photo.jpg" {
+		t.Fatalf("unexpected photo: %#v", photo)
+	}
+
+	photo, err = client.FetchPhoto(context.Background(), req, PhotoOptions{ReturnURL: true})
+	if err != nil {
+		t.Fatalf("fetch photo url error: %v", err)
+	}
+	if photo.URL != server.URL+"/cdn/photo.jpg" || photo.Data != nil || cdnHits != 1 {
+		t.Fatalf("expected only the redirect URL, got %#v (cdn hits %d)", photo, cdnHits)
+	}
+
+	if _, err := client.FetchPhoto(context.Background(), PhotoMediaRequest{}, PhotoOptions{}); err == nil {
+		t.Fatalf("expected validation error")
+	}
+}
+
+func TestFetchPhotoErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/loop/media") {
+			http.Redirect(w, r, r.URL.Path, http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": {"code": 404, "message": "Photo not found.", "status": "NOT_FOUND"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	_, err := client.FetchPhoto(context.Background(), PhotoMediaRequest{Name: "places/p/photos/missing"}, PhotoOptions{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != "NOT_FOUND" {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	_, err = client.FetchPhoto(context.Background(), PhotoMediaRequest{Name: "places/p/photos/loop"}, PhotoOptions{})
+	if err == nil || !strings.Contains(err.Error(), "redirects") {
+		t.Fatalf("expected redirect limit error, got %v", err)
+	}
+}
+
 func TestDetailsSuccess(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/v1/places/place-123" {
@@ -374,7 +489,7 @@ func TestDetailsSuccess(t *testing.T) {
 		if r.URL.Query().Get("regionCode") != "US" {
 			t.Fatalf("unexpected regionCode: %s", r.URL.Query().Get("regionCode"))
 		}
-		if r.Header.Get("X-Goog-FieldMask") != detailsFieldMaskBase {
+		if r.Header.Get("X-Goog-FieldMask") != detailsFieldMaskBase+","+FieldEditorialSummary {
 			t.Fatalf("unexpected field mask: %s", r.Header.Get("X-Goog-FieldMask"))
 		}
 		_, _ = w.Write([]byte(`{
@@ -388,16 +503,19 @@ func TestDetailsSuccess(t *testing.T) {
   "regularOpeningHours": {"weekdayDescriptions": ["Mon: 9-5"]},
   "currentOpeningHours": {"openNow": false},
   "nationalPhoneNumber": "+1 555",
-  "websiteUri": "https://example.com"
+  "websiteUri": "https://example.com",
+  "editorialSummary": {"text": "City park.", "languageCode": "en"},
+  "priceRange": {"startPrice": {"currencyCode": "USD", "units": "20"}, "endPrice": {"currencyCode": "USD", "units": "30", "nanos": 500000000}}
 }`))
 	}))
 	defer server.Close()
 
 	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL + "/v1"})
 	place, err := client.DetailsWithOptions(context.Background(), DetailsRequest{
-		PlaceID:  "place-123",
-		Language: "en",
-		Region:   "US",
+		PlaceID:                 "place-123",
+		Language:                "en",
+		Region:                  "US",
+		IncludeEditorialSummary: true,
 	})
 	if err != nil {
 		t.Fatalf("details error: %v", err)
@@ -411,6 +529,25 @@ func TestDetailsSuccess(t *testing.T) {
 	if len(place.Hours) != 1 {
 		t.Fatalf("unexpected hours")
 	}
+	if place.EditorialSummary == nil || place.EditorialSummary.Text != "City park." || place.EditorialSummary.LanguageCode != "en" {
+		t.Fatalf("unexpected editorial summary: %#v", place.EditorialSummary)
+	}
+	if place.PriceRange == nil || place.PriceRange.Currency != "USD" || *place.PriceRange.StartAmount != 20 || *place.PriceRange.EndAmount != 30.5 {
+		t.Fatalf("unexpected price range: %#v", place.PriceRange)
+	}
+}
+
+func TestMapPriceRange(t *testing.T) {
+	openEnded := mapPriceRange(&priceRangePayload{StartPrice: &moneyPayload{CurrencyCode: "EUR", Units: "100"}})
+	if openEnded == nil || openEnded.Currency != "EUR" || *openEnded.StartAmount != 100 || openEnded.EndAmount != nil {
+		t.Fatalf("unexpected open-ended range: %#v", openEnded)
+	}
+	if mapPriceRange(nil) != nil || mapPriceRange(&priceRangePayload{}) != nil {
+		t.Fatalf("expected nil for missing ranges")
+	}
+	if mapPriceRange(&priceRangePayload{StartPrice: &moneyPayload{Units: "lots"}}) != nil {
+		t.Fatalf("expected nil for unparsable units")
+	}
 }
 
 func TestDetailsWithReviews(t *testing.T) {
@@ -502,6 +639,12 @@ func TestDetailsFieldMaskForRequest(t *testing.T) {
 	if got := detailsFieldMaskForRequest(req); got != detailsFieldMaskBase {
 		t.Fatalf("unexpected field mask: %s", got)
 	}
+	if strings.Contains(detailsFieldMaskBase, "editorialSummary") {
+		t.Fatalf("editorial summary should be opt-in: %s", detailsFieldMaskBase)
+	}
+	if got := detailsFieldMaskForRequest(DetailsRequest{IncludeEditorialSummary: true}); !strings.Contains(got, "editorialSummary") {
+		t.Fatalf("expected editorialSummary in field mask: %s", got)
+	}
 	req.IncludeReviews = true
 	got := detailsFieldMaskForRequest(req)
 	if !strings.Contains(got, "reviews") {
@@ -566,7 +709,310 @@ func TestResolveSuccess(t *testing.T) {
 	}
 }
 
+func TestHTTPClientTransportUsedForEveryEndpoint(t *testing.T) {
+	transport := &stubTransport{}
+	client := NewClient(Options{APIKey: "test-key", HTTPClient: &http.Client{Transport: transport}, UserAgent: "fleet/1.0"})
+	ctx := context.Background()
+
+	calls := []struct {
+		endpoint string
+		call     func() error
+	}{
+		{"places.googleapis.com/v1/places:searchText", func() error {
+			_, err := client.Search(ctx, SearchRequest{Query: "coffee"})
+			return err
+		}},
+		{"places.googleapis.com/v1/places:searchNearby", func() error {
+			_, err := client.NearbySearch(ctx, NearbySearchRequest{
+				LocationRestriction: &LocationBias{Lat: 1, Lng: 2, RadiusM: 100},
+			})
+			return err
+		}},
+		{"places.googleapis.com/v1/places:autocomplete", func() error {
+			_, err := client.Autocomplete(ctx, AutocompleteRequest{Input: "cof"})
+			return err
+		}},
+		{"places.googleapis.com/v1/places/abc", func() error {
+			_, err := client.Details(ctx, "abc")
+			return err
+		}},
+		{"places.googleapis.com/v1/places/abc/photos/p1/media", func() error {
+			if _, err := client.PhotoMedia(ctx, PhotoMediaRequest{Name: "places/abc/photos/p1"}); err != nil {
+				return err
+			}
+			_, err := client.FetchPhoto(ctx, PhotoMediaRequest{Name: "places/abc/photos/p1"}, PhotoOptions{})
+			return err
+		}},
+		{"routes.googleapis.com/directions/v2:computeRoutes", func() error {
+			_, err := client.Route(ctx, RouteRequest{Query: "coffee", From: "A", To: "B"})
+			return err
+		}},
+		{"maps.googleapis.com/maps/api/directions/json", func() error {
+			_, err := client.Directions(ctx, DirectionsRequest{From: "A", To: "B"})
+			return err
+		}},
+		{"maps.googleapis.com/maps/api/geocode/json", func() error {
+			_, err := client.ValidateLocations(ctx, []string{"Main St"}, BatchOptions{})
+			return err
+		}},
+	}
+	for _, tc := range calls {
+		if err := tc.call(); err != nil {
+			t.Fatalf("%s: %v", tc.endpoint, err)
+		}
+		if !transport.saw(tc.endpoint) {
+			t.Fatalf("custom transport not used for %s (saw %v)", tc.endpoint, transport.requests)
+		}
+	}
+	for i, agent := range transport.userAgents {
+		if agent != "fleet/1.0" {
+			t.Fatalf("request %s sent User-Agent %q", transport.requests[i], agent)
+		}
+	}
+}
+
+func TestNewClientNilHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{"steps": []}]}]}`))
+	}))
+	defer server.Close()
+
+	// Only what a test server needs; everything else is the zero value.
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	if client.httpClient == nil || client.httpClient == http.DefaultClient || client.httpClient.Timeout != 10*time.Second {
+		t.Fatalf("expected a dedicated default client, got %#v", client.httpClient)
+	}
+	if _, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B"}); err != nil {
+		t.Fatalf("Directions error: %v", err)
+	}
+}
+
+func TestOptionsTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL, Timeout: 20 * time.Millisecond})
+	_, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a wrapped deadline error, got %v", err)
+	}
+
+	// A shorter context deadline wins over a generous Timeout.
+	client = NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL, Timeout: time.Hour})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.Directions(ctx, DirectionsRequest{From: "A", To: "B"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the context deadline to apply, got %v", err)
+	}
+}
+
+func TestRequestHooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			if !strings.Contains(string(body), "coffee") {
+				t.Fatalf("hook consumed the request body: %q", body)
+			}
+			_, _ = w.Write([]byte(`{"places": []}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{"steps": []}]}]}`))
+	}))
+	defer server.Close()
+
+	var requests []*http.Request
+	var statuses []int
+	client := NewClient(Options{
+		APIKey:            "secret-key",
+		BaseURL:           server.URL,
+		DirectionsBaseURL: server.URL + "/directions",
+		OnRequest:         func(r *http.Request) { requests = append(requests, r) },
+		OnResponse: func(r *http.Response, latency time.Duration) {
+			if r == nil || latency < 0 {
+				t.Fatalf("unexpected response hook call: %v %v", r, latency)
+			}
+			statuses = append(statuses, r.StatusCode)
+		},
+	})
+	if _, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B"}); err != nil {
+		t.Fatalf("Directions error: %v", err)
+	}
+	if _, err := client.Search(context.Background(), SearchRequest{Query: "coffee"}); err != nil {
+		t.Fatalf("Search error: %v", err)
+	}
+
+	if len(requests) != 2 || !slices.Equal(statuses, []int{200, 200}) {
+		t.Fatalf("expected both hooks per request, got %d requests, statuses %v", len(requests), statuses)
+	}
+	if url := requests[0].URL.String(); strings.Contains(url, "secret-key") || !strings.Contains(url, "key=REDACTED") {
+		t.Fatalf("directions URL not redacted: %s", url)
+	}
+	if requests[1].Header.Get("X-Goog-Api-Key") != "REDACTED" {
+		t.Fatalf("places key header not redacted: %v", requests[1].Header)
+	}
+	if body, _ := io.ReadAll(requests[1].Body); !strings.Contains(string(body), "coffee") {
+		t.Fatalf("expected a readable body copy, got %q", body)
+	}
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			_, _ = w.Write([]byte(`{"places": []}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{"steps": []}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{
+		APIKey:            "secret-key",
+		BaseURL:           server.URL,
+		DirectionsBaseURL: server.URL + "/directions",
+		MaxResponseBytes:  16,
+	})
+	_, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B"})
+	if !errors.Is(err, ErrResponseTooLarge) || !strings.Contains(err.Error(), "more than 16 bytes") {
+		t.Fatalf("expected ErrResponseTooLarge from Directions, got %v", err)
+	}
+	if _, err := client.Search(context.Background(), SearchRequest{Query: "coffee"}); err != nil {
+		t.Fatalf("expected a body within the limit to pass, got %v", err)
+	}
+
+	client = NewClient(Options{APIKey: "secret-key", BaseURL: server.URL, MaxResponseBytes: 8})
+	if _, err := client.Search(context.Background(), SearchRequest{Query: "coffee"}); !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("expected ErrResponseTooLarge from Search, got %v", err)
+	}
+	if got := NewClient(Options{APIKey: "key"}).maxResponseBytes; got != DefaultMaxResponseBytes {
+		t.Fatalf("expected default limit, got %d", got)
+	}
+}
+
+func TestOversizedJSONNeverReachesDecoder(t *testing.T) {
+	// Valid JSON cut at the limit would fail with "unexpected end of JSON
+	// input"; every decode path must report the limit instead.
+	padding := strings.Repeat("x", 256)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/directions"):
+			_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"summary": "` + padding + `", "legs": [{"steps": []}]}]}`))
+		case strings.HasPrefix(r.URL.Path, "/geocode"):
+			_, _ = w.Write([]byte(`{"status": "OK", "results": [{"formatted_address": "` + padding + `"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"id": "place-1", "displayName": {"text": "` + padding + `"}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{
+		APIKey:            "secret-key",
+		BaseURL:           server.URL,
+		DirectionsBaseURL: server.URL + "/directions",
+		GeocodeBaseURL:    server.URL + "/geocode",
+		MaxResponseBytes:  128,
+	})
+	ctx := context.Background()
+	_, directionsErr := client.Directions(ctx, DirectionsRequest{From: "A", To: "B"})
+	_, geocodeErr := client.Geocode(ctx, GeocodeRequest{Address: "Main St"})
+	_, detailsErr := client.Details(ctx, "place-1")
+	for name, err := range map[string]error{"directions": directionsErr, "geocode": geocodeErr, "details": detailsErr} {
+		if !errors.Is(err, ErrResponseTooLarge) || !strings.Contains(err.Error(), "raise Options.MaxResponseBytes") ||
+			strings.Contains(err.Error(), "unexpected end of JSON") {
+			t.Fatalf("%s: expected an actionable size error, got %v", name, err)
+		}
+	}
+}
+
+func TestDefaultUserAgent(t *testing.T) {
+	transport := &stubTransport{}
+	client := NewClient(Options{APIKey: "test-key", HTTPClient: &http.Client{Transport: transport}})
+	if _, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B"}); err != nil {
+		t.Fatalf("directions: %v", err)
+	}
+	if len(transport.userAgents) != 1 || transport.userAgents[0] != "goplaces/"+Version {
+		t.Fatalf("unexpected User-Agent: %v", transport.userAgents)
+	}
+}
+
+// stubTransport answers every endpoint in-process and records what it served.
+type stubTransport struct {
+	mu         sync.Mutex
+	requests   []string
+	userAgents []string
+}
+
+func (s *stubTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	endpoint := r.URL.Host + r.URL.Path
+	s.mu.Lock()
+	s.requests = append(s.requests, endpoint)
+	s.userAgents = append(s.userAgents, r.Header.Get("User-Agent"))
+	s.mu.Unlock()
+
+	body := `{}`
+	contentType := "application/json"
+	switch {
+	case strings.HasSuffix(endpoint, ":computeRoutes"):
+		body = `{"routes": [{"polyline": {"encodedPolyline": "_p~iF~ps|U_ulLnnqC"}}]}`
+	case strings.HasSuffix(endpoint, "/directions/json"):
+		body = `{"status": "OK", "routes": [{"legs": [{"distance": {"value": 1}, "duration": {"value": 1}}]}]}`
+	case strings.HasSuffix(endpoint, "/geocode/json"):
+		body = `{"status": "ZERO_RESULTS", "results": []}`
+	case strings.HasSuffix(endpoint, "/media") && r.URL.Query().Get("skipHttpRedirect") == "":
+		body, contentType = "jpeg", "image/jpeg"
+	case strings.HasSuffix(endpoint, "/media"):
+		body = `{"photoUri": "https://lh3.example/p1"}`
+	case strings.HasSuffix(endpoint, "/places/abc"):
+		body = `{"id": "abc"}`
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{contentType}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}, nil
+}
+
+func (s *stubTransport) saw(endpoint string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Contains(s.requests, endpoint)
+}
+
+func TestNewClientWithError(t *testing.T) {
+	client, err := NewClientWithError(Options{APIKey: "test-key", BaseURL: "https://places.example.com/v1"})
+	if err != nil || client == nil {
+		t.Fatalf("expected valid client, got %v", err)
+	}
+	if _, err := NewClientWithError(Options{}); err != nil {
+		t.Fatalf("empty URLs should use defaults: %v", err)
+	}
+
+	cases := []struct {
+		opts  Options
+		field string
+	}{
+		{Options{BaseURL: "places.googleapis.com/v1"}, "base_url"},
+		{Options{RoutesBaseURL: "ftp://routes.example.com"}, "routes_base_url"},
+		{Options{DirectionsBaseURL: "://bad"}, "directions_base_url"},
+		{Options{GeocodeBaseURL: "https://"}, "geocode_base_url"},
+	}
+	for _, tc := range cases {
+		client, err := NewClientWithError(tc.opts)
+		var validation ValidationError
+		if client != nil || !errors.As(err, &validation) || validation.Field != tc.field {
+			t.Fatalf("expected %s validation error, got %v", tc.field, err)
+		}
+		if again := tc.opts.Validate(); again == nil || again.Error() != err.Error() {
+			t.Fatalf("expected Validate to be repeatable, got %v", again)
+		}
+	}
+}
+
 func TestMissingAPIKey(t *testing.T) {
+	t.Setenv("GOOGLE_PLACES_API_KEY", "")
+	t.Setenv("GOOGLE_MAPS_API_KEY", "")
 	client := NewClient(Options{})
 	_, err := client.Search(context.Background(), SearchRequest{Query: "coffee"})
 	if !errors.Is(err, ErrMissingAPIKey) {
@@ -574,6 +1020,35 @@ func TestMissingAPIKey(t *testing.T) {
 	}
 }
 
+func TestAPIKeyFromEnv(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.URL.Query().Get("key"))
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{"steps": []}]}]}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("GOOGLE_PLACES_API_KEY", "")
+	t.Setenv("GOOGLE_MAPS_API_KEY", "maps-key")
+	client := NewClient(Options{DirectionsBaseURL: server.URL})
+	if _, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B"}); err != nil {
+		t.Fatalf("Directions error: %v", err)
+	}
+
+	t.Setenv("GOOGLE_PLACES_API_KEY", "places-key")
+	client = NewClient(Options{DirectionsBaseURL: server.URL})
+	if _, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "C"}); err != nil {
+		t.Fatalf("Directions error: %v", err)
+	}
+	client = NewClient(Options{APIKey: "option-key", DirectionsBaseURL: server.URL})
+	if _, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "D"}); err != nil {
+		t.Fatalf("Directions error: %v", err)
+	}
+	if !slices.Equal(keys, []string{"maps-key", "places-key", "option-key"}) {
+		t.Fatalf("unexpected key precedence: %v", keys)
+	}
+}
+
 func TestValidationErrors(t *testing.T) {
 	client := NewClient(Options{APIKey: "test-key", BaseURL: "http://example.com"})
 
@@ -0,0 +1,53 @@
+package goplaces
+
+import (
+	"context"
+	"sync"
+)
+
+// flightGroup shares one in-flight call among concurrent callers with the
+// same key, like golang.org/x/sync/singleflight.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done    chan struct{}
+	payload []byte
+	err     error
+	// dups counts callers that joined instead of sending.
+	dups int
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{calls: map[string]*flightCall{}}
+}
+
+// do runs fn once per key at a time. Callers that arrive while it runs wait
+// for its result, or for their own context to end. The leader's context
+// governs the shared call, so its cancellation fails every waiter.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		call.dups++
+		g.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.payload, call.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	call := &flightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.payload, call.err = fn()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(call.done)
+	return call.payload, call.err
+}
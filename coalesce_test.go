@@ -0,0 +1,88 @@
+package goplaces
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalesceRequestsSharesOneCall(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		<-release
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{"distance": {"value": 7}, "duration": {"value": 1}}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL, CoalesceRequests: true})
+	const callers = 5
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for range callers {
+		wg.Go(func() {
+			response, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B"})
+			if err == nil && response.DistanceMeters != 7 {
+				t.Errorf("unexpected response: %#v", response)
+			}
+			errs <- err
+		})
+	}
+
+	// Hold the upstream call until every other caller has joined it.
+	deadline := time.Now().Add(time.Second)
+	for client.flightDups() < callers-1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("callers never joined the flight (%d)", client.flightDups())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("directions: %v", err)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected 1 upstream call, got %d", calls.Load())
+	}
+}
+
+func TestFlightGroupWaiterHonorsContext(t *testing.T) {
+	group := newFlightGroup()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go func() {
+		_, _ = group.do(context.Background(), "k", func() ([]byte, error) {
+			close(started)
+			<-release
+			return nil, nil
+		})
+	}()
+	<-started
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := group.do(ctx, "k", func() ([]byte, error) { return nil, nil }); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	close(release)
+}
+
+// flightDups counts callers waiting on another caller's in-flight request.
+func (c *Client) flightDups() int {
+	c.flights.mu.Lock()
+	defer c.flights.mu.Unlock()
+	dups := 0
+	for _, call := range c.flights.calls {
+		dups += call.dups
+	}
+	return dups
+}
@@ -0,0 +1,106 @@
+package goplaces
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// debugLogSize is how many exchanges Client.DebugLog keeps.
+	debugLogSize = 100
+	// debugBodyBytes is how much of each response body an entry keeps.
+	debugBodyBytes = 4 << 10
+)
+
+// DebugEntry is one HTTP exchange captured with Options.Debug.
+type DebugEntry struct {
+	Time   time.Time
+	Method string
+	// URL has the API key redacted.
+	URL        string
+	StatusCode int
+	// Body is the start of the response body, at most 4 KiB.
+	Body      string
+	Truncated bool
+	// Err is the transport error, when the request got no response.
+	Err string
+}
+
+// DebugLog returns the most recent exchanges (up to 100), oldest first, or
+// nil unless Options.Debug is set.
+func (c *Client) DebugLog() []DebugEntry {
+	if c.debug == nil {
+		return nil
+	}
+	return c.debug.entries()
+}
+
+// debugRing is a fixed-size log that overwrites its oldest entry.
+type debugRing struct {
+	mu    sync.Mutex
+	items []DebugEntry
+	next  int
+}
+
+func (r *debugRing) add(entry DebugEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.items) < debugLogSize {
+		r.items = append(r.items, entry)
+		return
+	}
+	r.items[r.next] = entry
+	r.next = (r.next + 1) % debugLogSize
+}
+
+func (r *debugRing) entries() []DebugEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries := make([]DebugEntry, 0, len(r.items))
+	entries = append(entries, r.items[r.next:]...)
+	return append(entries, r.items[:r.next]...)
+}
+
+// debugTransport records every round trip. It reads only the first
+// debugBodyBytes of a body and hands the caller the full stream.
+type debugTransport struct {
+	base http.RoundTripper
+	log  *debugRing
+}
+
+func (t *debugTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	entry := DebugEntry{Time: time.Now(), Method: request.Method, URL: redactKey(request.URL.String())}
+	response, err := t.base.RoundTrip(request)
+	if err != nil {
+		entry.Err = redactKey(err.Error())
+		t.log.add(entry)
+		return response, err
+	}
+	entry.StatusCode = response.StatusCode
+
+	// A read error here recurs when the caller reads on, so it is not kept.
+	head, _ := io.ReadAll(io.LimitReader(response.Body, debugBodyBytes+1))
+	entry.Truncated = len(head) > debugBodyBytes
+	entry.Body = redactKey(string(head[:min(len(head), debugBodyBytes)]))
+	response.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), response.Body), response.Body}
+	t.log.add(entry)
+	return response, nil
+}
+
+// withDebugTransport returns a copy of client that records into log, so a
+// caller-supplied client is never modified.
+func withDebugTransport(client *http.Client, log *debugRing) *http.Client {
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	debugClient := *client
+	debugClient.Transport = &debugTransport{base: base, log: log}
+	return &debugClient
+}
@@ -0,0 +1,95 @@
+package goplaces
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDebugLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"summary": "` + r.URL.Query().Get("origin") + `", "legs": [{"steps": []}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{
+		APIKey:            "secret-key",
+		DirectionsBaseURL: server.URL,
+		Debug:             true,
+	})
+	for _, from := range []string{"A", "C"} {
+		response, err := client.Directions(context.Background(), DirectionsRequest{From: from, To: "B"})
+		if err != nil {
+			t.Fatalf("Directions error: %v", err)
+		}
+		if response.Summary != from {
+			t.Fatalf("debug transport changed the body: %#v", response)
+		}
+	}
+
+	entries := client.DebugLog()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	for i, from := range []string{"A", "C"} {
+		entry := entries[i]
+		if entry.Method != http.MethodGet || entry.StatusCode != http.StatusOK || entry.Time.IsZero() {
+			t.Fatalf("unexpected entry: %#v", entry)
+		}
+		if strings.Contains(entry.URL, "secret-key") || !strings.Contains(entry.URL, "key=REDACTED") ||
+			!strings.Contains(entry.URL, "origin="+from) {
+			t.Fatalf("unexpected URL: %s", entry.URL)
+		}
+		if !strings.Contains(entry.Body, `"summary": "`+from+`"`) || entry.Truncated {
+			t.Fatalf("unexpected body: %q", entry.Body)
+		}
+	}
+
+	if NewClient(Options{APIKey: "key"}).DebugLog() != nil {
+		t.Fatalf("expected no log without Options.Debug")
+	}
+}
+
+func TestDebugRingKeepsNewest(t *testing.T) {
+	ring := &debugRing{}
+	for i := range debugLogSize + 5 {
+		ring.add(DebugEntry{StatusCode: i})
+	}
+	entries := ring.entries()
+	if len(entries) != debugLogSize || entries[0].StatusCode != 5 || entries[len(entries)-1].StatusCode != debugLogSize+4 {
+		t.Fatalf("unexpected ring contents: first %d, last %d, len %d",
+			entries[0].StatusCode, entries[len(entries)-1].StatusCode, len(entries))
+	}
+}
+
+func TestDebugTransportTruncatesAndErrors(t *testing.T) {
+	large := strings.Repeat("x", debugBodyBytes+10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(large))
+	}))
+	log := &debugRing{}
+	client := withDebugTransport(&http.Client{}, log)
+	response, err := client.Get(server.URL + "?key=secret")
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	body, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil || string(body) != large {
+		t.Fatalf("caller lost body bytes: %d, %v", len(body), err)
+	}
+	if entry := log.entries()[0]; !entry.Truncated || len(entry.Body) != debugBodyBytes {
+		t.Fatalf("expected a truncated body, got %d bytes", len(entry.Body))
+	}
+
+	server.Close()
+	if _, err := client.Get(server.URL + "?key=secret"); err == nil {
+		t.Fatalf("expected transport error")
+	}
+	if entry := log.entries()[1]; entry.Err == "" || strings.Contains(entry.Err, "secret") {
+		t.Fatalf("unexpected error entry: %#v", entry)
+	}
+}
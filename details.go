@@ -8,10 +8,20 @@ import (
 	"strings"
 )
 
-const (
-	detailsFieldMaskBase   = "id,displayName,formattedAddress,location,rating,priceLevel,types,regularOpeningHours,currentOpeningHours,nationalPhoneNumber,websiteUri"
-	detailsFieldMaskReview = "reviews"
-	detailsFieldMaskPhotos = "photos"
+var detailsFieldMaskBase = mustFieldMask(
+	FieldID,
+	FieldName,
+	FieldAddress,
+	FieldLocation,
+	FieldRating,
+	FieldUserRatingCount,
+	FieldPriceLevel,
+	FieldPriceRange,
+	FieldTypes,
+	FieldRegularOpeningHours,
+	FieldCurrentOpeningHours,
+	FieldPhone,
+	FieldWebsite,
 )
 
 // Details fetches details for a specific place ID.
@@ -29,6 +39,7 @@ func (c *Client) DetailsWithOptions(ctx context.Context, req DetailsRequest) (Pl
 	endpoint, err := c.buildURL("/places/"+placeID, map[string]string{
 		"languageCode": strings.TrimSpace(req.Language),
 		"regionCode":   strings.TrimSpace(req.Region),
+		"sessionToken": strings.TrimSpace(req.SessionToken),
 	})
 	if err != nil {
 		return PlaceDetails{}, err
@@ -51,28 +62,35 @@ func detailsFieldMaskForRequest(req DetailsRequest) string {
 	fields := []string{detailsFieldMaskBase}
 	if req.IncludeReviews {
 		// Reviews are heavy; opt-in to include them.
-		fields = append(fields, detailsFieldMaskReview)
+		fields = append(fields, FieldReviews)
 	}
 	if req.IncludePhotos {
-		fields = append(fields, detailsFieldMaskPhotos)
+		fields = append(fields, FieldPhotos)
+	}
+	if req.IncludeEditorialSummary {
+		// The summary bills at the Atmosphere tier; opt-in like reviews.
+		fields = append(fields, FieldEditorialSummary)
 	}
 	return strings.Join(fields, ",")
 }
 
 func mapPlaceDetails(place placeItem) PlaceDetails {
 	return PlaceDetails{
-		PlaceID:    place.ID,
-		Name:       displayName(place.DisplayName),
-		Address:    place.FormattedAddress,
-		Location:   mapLatLng(place.Location),
-		Rating:     place.Rating,
-		PriceLevel: mapPriceLevel(place.PriceLevel),
-		Types:      place.Types,
-		Phone:      place.NationalPhoneNumber,
-		Website:    place.WebsiteURI,
-		Hours:      weekdayDescriptions(place.RegularOpeningHours),
-		OpenNow:    openNow(place.CurrentOpeningHours),
-		Reviews:    mapReviews(place.Reviews),
-		Photos:     mapPhotos(place.Photos),
+		PlaceID:          place.ID,
+		Name:             displayName(place.DisplayName),
+		Address:          place.FormattedAddress,
+		Location:         mapLatLng(place.Location),
+		Rating:           place.Rating,
+		UserRatingCount:  place.UserRatingCount,
+		PriceLevel:       mapPriceLevel(place.PriceLevel),
+		PriceRange:       mapPriceRange(place.PriceRange),
+		Types:            place.Types,
+		Phone:            place.NationalPhoneNumber,
+		Website:          place.WebsiteURI,
+		Hours:            weekdayDescriptions(place.RegularOpeningHours),
+		OpenNow:          openNow(place.CurrentOpeningHours),
+		Reviews:          mapReviews(place.Reviews),
+		Photos:           mapPhotos(place.Photos),
+		EditorialSummary: mapLocalizedText(place.EditorialSummary),
 	}
 }
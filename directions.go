@@ -1,16 +1,19 @@
 package goplaces
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"html"
-	"io"
 	"net/http"
 	"net/url"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -41,6 +44,52 @@ var directionsUnits = map[string]struct{}{
 	directionsUnitsImperial: {},
 }
 
+// originDriftWarnMeters is how far a route may start from a lat/lng origin
+// before Directions adds a warning; Google snaps to the nearest road, which
+// is rarely more than a few kilometers away.
+const originDriftWarnMeters = 50_000.0
+
+// maxDirectionsWaypoints is Google's limit for intermediate waypoints.
+const maxDirectionsWaypoints = 25
+
+const (
+	instructionFormatPlain    = "plain"
+	instructionFormatHTML     = "html"
+	instructionFormatMarkdown = "markdown"
+)
+
+var instructionFormats = map[string]struct{}{
+	instructionFormatPlain:    {},
+	instructionFormatHTML:     {},
+	instructionFormatMarkdown: {},
+}
+
+var directionsTrafficModels = map[string]struct{}{
+	"best_guess":  {},
+	"pessimistic": {},
+	"optimistic":  {},
+}
+
+var directionsTransitModes = map[string]struct{}{
+	"bus":    {},
+	"subway": {},
+	"train":  {},
+	"tram":   {},
+	"rail":   {},
+}
+
+var directionsTransitPreferences = map[string]struct{}{
+	"less_walking":    {},
+	"fewer_transfers": {},
+}
+
+var directionsAvoidFeatures = map[string]struct{}{
+	"tolls":    {},
+	"highways": {},
+	"ferries":  {},
+	"indoor":   {},
+}
+
 // DirectionsRequest describes a directions query between two locations.
 type DirectionsRequest struct {
 	From         string  `json:"from,omitempty"`
@@ -53,6 +102,43 @@ type DirectionsRequest struct {
 	Language     string  `json:"language,omitempty"`
 	Region       string  `json:"region,omitempty"`
 	Units        string  `json:"units,omitempty"`
+	// DepartureTime requests a departure at the given time (transit/driving).
+	DepartureTime *time.Time `json:"departure_time,omitempty"`
+	// ArrivalTime asks for a transit route arriving by the given time. It
+	// cannot be combined with DepartureTime.
+	ArrivalTime *time.Time `json:"arrival_time,omitempty"`
+	// TrafficModel is best_guess, pessimistic, or optimistic. Google only
+	// honors it for driving with a DepartureTime, so other combinations fail
+	// validation instead of being ignored.
+	TrafficModel string `json:"traffic_model,omitempty"`
+	// Waypoints are intermediate stops, each an address, "place_id:ID", or
+	// "lat,lng". Each hop becomes one entry in DirectionsResponse.Legs.
+	Waypoints []string `json:"waypoints,omitempty"`
+	// OptimizeWaypoints lets Google reorder Waypoints for the shortest route;
+	// the chosen order is returned in DirectionsResponse.WaypointOrder.
+	OptimizeWaypoints bool `json:"optimize_waypoints,omitempty"`
+	// Alternatives asks Google for more than one route. Directions still
+	// returns the first; DirectionsAll returns them all.
+	Alternatives bool `json:"alternatives,omitempty"`
+	// Avoid lists features to route around: tolls, highways, ferries, or
+	// indoor (walking and transit steps inside buildings).
+	Avoid []string `json:"avoid,omitempty"`
+	// TransitModes limits transit routes to bus, subway, train, tram, or rail
+	// ("rail" covers train, tram, and subway).
+	TransitModes []string `json:"transit_modes,omitempty"`
+	// TransitRoutingPreference is less_walking or fewer_transfers.
+	TransitRoutingPreference string `json:"transit_routing_preference,omitempty"`
+	// NoModeDefaults sends the request as given, skipping the per-mode
+	// defaults (transit departs now; timed drives use best_guess traffic).
+	NoModeDefaults bool `json:"no_mode_defaults,omitempty"`
+	// FromHeading is the direction the vehicle faces at FromLocation, in
+	// degrees clockwise from north (0-359), so a drive starts that way instead
+	// of with a U-turn. It requires FromLocation and Mode drive.
+	FromHeading *int `json:"from_heading,omitempty"`
+	// InstructionFormat shapes DirectionsStep.Instruction: plain (default)
+	// strips Google's HTML, html keeps it verbatim, and markdown keeps bold
+	// street names as **bold**.
+	InstructionFormat string `json:"instruction_format,omitempty"`
 }
 
 // DirectionsResponse contains a single route summary and steps.
@@ -67,6 +153,62 @@ type DirectionsResponse struct {
 	DurationSeconds int              `json:"duration_seconds,omitempty"`
 	Warnings        []string         `json:"warnings,omitempty"`
 	Steps           []DirectionsStep `json:"steps,omitempty"`
+	// Legs has one entry per origin/waypoint/destination hop; with several
+	// legs the top-level distance, duration, and steps cover all of them.
+	Legs []DirectionsLeg `json:"legs,omitempty"`
+	// EncodedPolyline is the route overview in Google's encoded polyline
+	// format (overview_polyline); DecodePolyline turns it into points.
+	EncodedPolyline string `json:"encoded_polyline,omitempty"`
+	// GeocodedWaypoints reports how Google geocoded the origin (first) and destination (last).
+	GeocodedWaypoints []GeocodedWaypoint `json:"geocoded_waypoints,omitempty"`
+	// Units is the unit system of the text fields ("metric" or "imperial").
+	Units string `json:"units,omitempty"`
+	// Status is Google's raw status string (currently always "OK" on success).
+	Status string `json:"status,omitempty"`
+	// DurationInTraffic* are set only when Google returns traffic data (driving with a departure time).
+	DurationInTrafficText    string `json:"duration_in_traffic_text,omitempty"`
+	DurationInTrafficSeconds int    `json:"duration_in_traffic_seconds,omitempty"`
+	// WaypointOrder is the visiting order of request Waypoints (indexes into
+	// DirectionsRequest.Waypoints) when OptimizeWaypoints is set.
+	WaypointOrder []int `json:"waypoint_order,omitempty"`
+	// Bounds is the viewport containing the whole route, for fitting a map.
+	Bounds *LatLngBounds `json:"bounds,omitempty"`
+	// Fare is the total ticket price Google estimates for some transit
+	// routes; nil when it is not known.
+	Fare *Fare `json:"fare,omitempty"`
+}
+
+// Fare is a transit fare, e.g. {USD, "$6.00", 6}.
+type Fare struct {
+	// Currency is an ISO 4217 code.
+	Currency string  `json:"currency"`
+	Text     string  `json:"text,omitempty"`
+	Value    float64 `json:"value"`
+}
+
+// DirectionsLeg is one hop of a route, e.g. origin to the first waypoint.
+type DirectionsLeg struct {
+	StartAddress    string  `json:"start_address,omitempty"`
+	EndAddress      string  `json:"end_address,omitempty"`
+	StartLocation   *LatLng `json:"start_location,omitempty"`
+	EndLocation     *LatLng `json:"end_location,omitempty"`
+	DistanceText    string  `json:"distance_text,omitempty"`
+	DistanceMeters  int     `json:"distance_meters,omitempty"`
+	DurationText    string  `json:"duration_text,omitempty"`
+	DurationSeconds int     `json:"duration_seconds,omitempty"`
+	// DurationInTraffic* are set only when Google returns traffic data for this leg.
+	DurationInTrafficText    string           `json:"duration_in_traffic_text,omitempty"`
+	DurationInTrafficSeconds int              `json:"duration_in_traffic_seconds,omitempty"`
+	Steps                    []DirectionsStep `json:"steps,omitempty"`
+}
+
+// GeocodedWaypoint describes how a text origin, destination, or waypoint was geocoded.
+type GeocodedWaypoint struct {
+	Status string `json:"status,omitempty"`
+	// PartialMatch means Google could not match the full input and guessed.
+	PartialMatch bool     `json:"partial_match,omitempty"`
+	PlaceID      string   `json:"place_id,omitempty"`
+	Types        []string `json:"types,omitempty"`
 }
 
 // DirectionsStep is a single navigation step.
@@ -78,22 +220,108 @@ type DirectionsStep struct {
 	DurationSeconds int    `json:"duration_seconds,omitempty"`
 	TravelMode      string `json:"travel_mode,omitempty"`
 	Maneuver        string `json:"maneuver,omitempty"`
+	Polyline        string `json:"polyline,omitempty"`
+	// TransitDetails is set only for TRANSIT steps.
+	TransitDetails *TransitDetails `json:"transit_details,omitempty"`
+}
+
+// TransitDetails describes the vehicle ride of a transit step.
+type TransitDetails struct {
+	LineShortName string `json:"line_short_name,omitempty"`
+	LineName      string `json:"line_name,omitempty"`
+	// VehicleType is Google's enum, e.g. BUS, SUBWAY, TRAM, HEAVY_RAIL.
+	VehicleType   string `json:"vehicle_type,omitempty"`
+	Headsign      string `json:"headsign,omitempty"`
+	NumStops      int    `json:"num_stops,omitempty"`
+	DepartureStop string `json:"departure_stop,omitempty"`
+	ArrivalStop   string `json:"arrival_stop,omitempty"`
+	// Times are in the stop's time zone; *TimeText is Google's localized form.
+	DepartureTime     *time.Time `json:"departure_time,omitempty"`
+	DepartureTimeText string     `json:"departure_time_text,omitempty"`
+	ArrivalTime       *time.Time `json:"arrival_time,omitempty"`
+	ArrivalTimeText   string     `json:"arrival_time_text,omitempty"`
 }
 
 // Directions fetches directions between two locations using the Google Directions API.
+// With Alternatives set, Google may return several routes; Directions keeps
+// the first (recommended) one. Use DirectionsAll for the rest.
 func (c *Client) Directions(ctx context.Context, req DirectionsRequest) (DirectionsResponse, error) {
+	_, response, err := c.DirectionsRaw(ctx, req)
+	return response, err
+}
+
+// DirectionsRaw is Directions that also returns Google's response body,
+// byte for byte (HTML instructions, unmapped fields), for debugging. With a
+// Cache the body may come from the cache; it is a copy either way.
+func (c *Client) DirectionsRaw(ctx context.Context, req DirectionsRequest) ([]byte, DirectionsResponse, error) {
+	req, apiResponse, err := c.fetchDirections(ctx, req)
+	if err != nil {
+		return nil, DirectionsResponse{}, err
+	}
+	response := c.mapDirectionsRoute(req, apiResponse, apiResponse.Routes[0])
+	if c.warningsAreErrors && len(response.Warnings) > 0 {
+		return nil, DirectionsResponse{}, &RouteWarningError{Warnings: response.Warnings}
+	}
+	return bytes.Clone(apiResponse.raw), response, nil
+}
+
+// DirectionsAll returns every route Google found, recommended first. Set
+// Alternatives to ask for more than one. With WarningsAreErrors, routes
+// carrying warnings are dropped, and an error is returned only if none remain.
+func (c *Client) DirectionsAll(ctx context.Context, req DirectionsRequest) (DirectionsResult, error) {
+	req, apiResponse, err := c.fetchDirections(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	result := make(DirectionsResult, 0, len(apiResponse.Routes))
+	var firstWarnings []string
+	for _, route := range apiResponse.Routes {
+		if len(route.Legs) == 0 {
+			continue
+		}
+		response := c.mapDirectionsRoute(req, apiResponse, route)
+		if c.warningsAreErrors && len(response.Warnings) > 0 {
+			if firstWarnings == nil {
+				firstWarnings = response.Warnings
+			}
+			continue
+		}
+		result = append(result, response)
+	}
+	if len(result) == 0 {
+		return nil, &RouteWarningError{Warnings: firstWarnings}
+	}
+	return result, nil
+}
+
+// fetchDirections validates req, calls the API, and returns the normalized
+// request with a response holding at least one route with legs.
+func (c *Client) fetchDirections(ctx context.Context, req DirectionsRequest) (DirectionsRequest, directionsAPIResponse, error) {
+	explicitUnits := strings.TrimSpace(req.Units) != ""
 	req = applyDirectionsDefaults(req)
 	if err := validateDirectionsRequest(req); err != nil {
-		return DirectionsResponse{}, err
+		return req, directionsAPIResponse{}, err
+	}
+	if !req.NoModeDefaults {
+		req = applyModeDefaults(req, c.now())
+	}
+	if !explicitUnits && c.autoUnits {
+		// Best-effort: a failed lookup keeps the metric default.
+		if units, err := c.unitsForDestination(ctx, req); err == nil {
+			req.Units = units
+		}
 	}
 
 	origin, err := resolveDirectionsLocation("from", req.FromPlaceID, req.FromLocation, req.From)
 	if err != nil {
-		return DirectionsResponse{}, err
+		return req, directionsAPIResponse{}, err
+	}
+	if req.FromHeading != nil {
+		origin = fmt.Sprintf("heading=%d:%s", *req.FromHeading, origin)
 	}
 	destination, err := resolveDirectionsLocation("to", req.ToPlaceID, req.ToLocation, req.To)
 	if err != nil {
-		return DirectionsResponse{}, err
+		return req, directionsAPIResponse{}, err
 	}
 
 	query := map[string]string{
@@ -104,61 +332,156 @@ func (c *Client) Directions(ctx context.Context, req DirectionsRequest) (Directi
 	if strings.TrimSpace(req.Language) != "" {
 		query["language"] = req.Language
 	}
+	if strings.TrimSpace(req.Region) == "" && c.autoRegion && req.FromLocation != nil {
+		// Best-effort: a failed lookup falls back to Google's default locale.
+		if region, err := c.regionForLocation(ctx, *req.FromLocation); err == nil {
+			req.Region = region
+		}
+	}
 	if strings.TrimSpace(req.Region) != "" {
 		query["region"] = req.Region
 	}
 	if strings.TrimSpace(req.Units) != "" {
 		query["units"] = req.Units
 	}
+	if req.DepartureTime != nil {
+		query["departure_time"] = strconv.FormatInt(req.DepartureTime.Unix(), 10)
+	}
+	if req.ArrivalTime != nil {
+		query["arrival_time"] = strconv.FormatInt(req.ArrivalTime.Unix(), 10)
+	}
+	if req.TrafficModel != "" {
+		query["traffic_model"] = req.TrafficModel
+	}
+	if len(req.Waypoints) > 0 {
+		waypoints := strings.Join(req.Waypoints, "|")
+		if req.OptimizeWaypoints {
+			waypoints = "optimize:true|" + waypoints
+		}
+		query["waypoints"] = waypoints
+	}
+	if req.Alternatives {
+		query["alternatives"] = "true"
+	}
+	if len(req.Avoid) > 0 {
+		query["avoid"] = strings.Join(req.Avoid, "|")
+	}
+	if len(req.TransitModes) > 0 {
+		query["transit_mode"] = strings.Join(req.TransitModes, "|")
+	}
+	if req.TransitRoutingPreference != "" {
+		query["transit_routing_preference"] = req.TransitRoutingPreference
+	}
 
-	endpoint, err := buildDirectionsURL(c.directionsBaseURL, query, c.apiKey)
+	endpoint, err := buildDirectionsURL(c.directionsEndpoint, query, c.apiKey, c.maxURLLength)
 	if err != nil {
-		return DirectionsResponse{}, err
+		return req, directionsAPIResponse{}, err
 	}
 
 	payload, err := c.doDirectionsRequest(ctx, endpoint)
 	if err != nil {
-		return DirectionsResponse{}, err
+		return req, directionsAPIResponse{}, err
 	}
 
 	var apiResponse directionsAPIResponse
 	if err := json.Unmarshal(payload, &apiResponse); err != nil {
-		return DirectionsResponse{}, fmt.Errorf("goplaces: decode directions response: %w", err)
+		return req, directionsAPIResponse{}, fmt.Errorf("goplaces: decode directions response: %w", err)
 	}
 	if apiResponse.Status != "OK" {
-		return DirectionsResponse{}, fmt.Errorf("goplaces: directions status %s: %s", apiResponse.Status, strings.TrimSpace(apiResponse.ErrorMessage))
+		return req, directionsAPIResponse{}, statusError("directions", apiResponse.Status, apiResponse.ErrorMessage)
 	}
 	if len(apiResponse.Routes) == 0 || len(apiResponse.Routes[0].Legs) == 0 {
-		return DirectionsResponse{}, errors.New("goplaces: no directions returned")
+		return req, directionsAPIResponse{}, fmt.Errorf("%w: response has no routes", ErrNoRoute)
 	}
+	apiResponse.raw = payload
+	return req, apiResponse, nil
+}
 
-	route := apiResponse.Routes[0]
-	leg := route.Legs[0]
-	steps := make([]DirectionsStep, 0, len(leg.Steps))
-	for _, step := range leg.Steps {
-		steps = append(steps, DirectionsStep{
-			Instruction:     cleanInstruction(step.HTMLInstructions),
-			DistanceText:    step.Distance.Text,
-			DistanceMeters:  step.Distance.Value,
-			DurationText:    step.Duration.Text,
-			DurationSeconds: step.Duration.Value,
-			TravelMode:      step.TravelMode,
-			Maneuver:        step.Maneuver,
-		})
+// mapDirectionsRoute converts one API route, adding the origin drift warning.
+func (c *Client) mapDirectionsRoute(req DirectionsRequest, apiResponse directionsAPIResponse, route directionsRoute) DirectionsResponse {
+	legs := make([]DirectionsLeg, 0, len(route.Legs))
+	for _, leg := range route.Legs {
+		legs = append(legs, mapDirectionsLeg(leg, req.InstructionFormat))
 	}
+	first, last := legs[0], legs[len(legs)-1]
 
-	return DirectionsResponse{
-		Mode:            strings.ToUpper(req.Mode),
-		Summary:         route.Summary,
-		StartAddress:    leg.StartAddress,
-		EndAddress:      leg.EndAddress,
-		DistanceText:    leg.Distance.Text,
-		DistanceMeters:  leg.Distance.Value,
-		DurationText:    leg.Duration.Text,
-		DurationSeconds: leg.Duration.Value,
-		Warnings:        route.Warnings,
-		Steps:           steps,
-	}, nil
+	response := DirectionsResponse{
+		Mode:                     strings.ToUpper(req.Mode),
+		Summary:                  route.Summary,
+		StartAddress:             first.StartAddress,
+		EndAddress:               last.EndAddress,
+		DistanceText:             first.DistanceText,
+		DistanceMeters:           first.DistanceMeters,
+		DurationText:             first.DurationText,
+		DurationSeconds:          first.DurationSeconds,
+		Warnings:                 route.Warnings,
+		Steps:                    first.Steps,
+		Legs:                     legs,
+		EncodedPolyline:          route.OverviewPolyline.Points,
+		GeocodedWaypoints:        mapGeocodedWaypoints(apiResponse.GeocodedWaypoints),
+		Units:                    req.Units,
+		Status:                   apiResponse.Status,
+		DurationInTrafficText:    first.DurationInTrafficText,
+		DurationInTrafficSeconds: first.DurationInTrafficSeconds,
+		WaypointOrder:            waypointOrder(req, route.WaypointOrder),
+	}
+	if len(legs) > 1 {
+		sumLegs(&response, legs)
+	}
+	if route.Bounds != nil {
+		response.Bounds = &LatLngBounds{
+			Northeast: LatLng{Lat: route.Bounds.Northeast.Lat, Lng: route.Bounds.Northeast.Lng},
+			Southwest: LatLng{Lat: route.Bounds.Southwest.Lat, Lng: route.Bounds.Southwest.Lng},
+		}
+	}
+	if route.Fare != nil {
+		response.Fare = &Fare{Currency: route.Fare.Currency, Text: route.Fare.Text, Value: route.Fare.Value}
+	}
+	if req.FromLocation != nil {
+		if drift := response.OriginDrift(*req.FromLocation); drift > originDriftWarnMeters {
+			response.Warnings = append(response.Warnings, fmt.Sprintf(
+				"route starts %.0f km from the requested origin; check the from coordinates", drift/1000))
+		}
+	}
+	return response
+}
+
+// sumLegs makes the top-level fields of a waypoint route describe the whole
+// trip: distances and durations are summed, steps concatenated, and the texts
+// re-rendered, since Google only localizes them per leg. The in-traffic total
+// counts plain durations for legs without traffic data.
+func sumLegs(response *DirectionsResponse, legs []DirectionsLeg) {
+	response.DistanceMeters, response.DurationSeconds = 0, 0
+	response.Steps = nil
+	hasTraffic := false
+	trafficSeconds := 0
+	for _, leg := range legs {
+		response.DistanceMeters += leg.DistanceMeters
+		response.DurationSeconds += leg.DurationSeconds
+		response.Steps = append(response.Steps, leg.Steps...)
+		if leg.DurationInTrafficSeconds > 0 {
+			hasTraffic = true
+			trafficSeconds += leg.DurationInTrafficSeconds
+		} else {
+			trafficSeconds += leg.DurationSeconds
+		}
+	}
+	response.DistanceText = FormatDistance(response.DistanceMeters, response.Units)
+	response.DurationText = FormatDuration(response.DurationSeconds)
+	response.DurationInTrafficSeconds, response.DurationInTrafficText = 0, ""
+	if hasTraffic {
+		response.DurationInTrafficSeconds = trafficSeconds
+		response.DurationInTrafficText = FormatDuration(trafficSeconds)
+	}
+}
+
+// waypointOrder reports Google's order only for optimized requests; otherwise
+// it is always the identity.
+func waypointOrder(req DirectionsRequest, order []int) []int {
+	if !req.OptimizeWaypoints {
+		return nil
+	}
+	return order
 }
 
 func applyDirectionsDefaults(req DirectionsRequest) DirectionsRequest {
@@ -179,6 +502,52 @@ func applyDirectionsDefaults(req DirectionsRequest) DirectionsRequest {
 	if req.Units == "" {
 		req.Units = directionsUnitsMetric
 	}
+	req.TrafficModel = strings.ToLower(strings.TrimSpace(req.TrafficModel))
+	req.InstructionFormat = strings.ToLower(strings.TrimSpace(req.InstructionFormat))
+	if len(req.Waypoints) > 0 {
+		waypoints := make([]string, len(req.Waypoints))
+		for i, waypoint := range req.Waypoints {
+			waypoints[i] = strings.TrimSpace(waypoint)
+		}
+		req.Waypoints = waypoints
+	}
+	if len(req.Avoid) > 0 {
+		avoid := make([]string, len(req.Avoid))
+		for i, feature := range req.Avoid {
+			avoid[i] = strings.ToLower(strings.TrimSpace(feature))
+		}
+		req.Avoid = avoid
+	}
+	if len(req.TransitModes) > 0 {
+		modes := make([]string, len(req.TransitModes))
+		for i, mode := range req.TransitModes {
+			modes[i] = strings.ToLower(strings.TrimSpace(mode))
+		}
+		req.TransitModes = modes
+	}
+	req.TransitRoutingPreference = strings.ToLower(strings.TrimSpace(req.TransitRoutingPreference))
+	return req
+}
+
+// applyModeDefaults fills settings the caller left unset that make a mode's
+// results more useful. Explicit values always win:
+//   - transit without a departure or arrival time departs now, pinning the
+//     timetable Google uses (and TransitDetails times) to a known instant.
+//   - drive with a departure time uses the best_guess traffic model, so legs
+//     carry traffic-aware durations.
+//
+// It runs after validation, and only produces combinations that validate.
+func applyModeDefaults(req DirectionsRequest, now time.Time) DirectionsRequest {
+	switch req.Mode {
+	case directionsModeTransit:
+		if req.DepartureTime == nil && req.ArrivalTime == nil {
+			req.DepartureTime = &now
+		}
+	case directionsModeDrive:
+		if req.DepartureTime != nil && req.TrafficModel == "" {
+			req.TrafficModel = "best_guess"
+		}
+	}
 	return req
 }
 
@@ -197,6 +566,73 @@ func validateDirectionsRequest(req DirectionsRequest) error {
 			return ValidationError{Field: "units", Message: "must be metric or imperial"}
 		}
 	}
+	if req.ArrivalTime != nil {
+		if req.DepartureTime != nil {
+			return ValidationError{Field: "arrival_time", Message: "cannot be combined with departure_time"}
+		}
+		if normalizeDirectionsMode(req.Mode) != directionsModeTransit {
+			return ValidationError{Field: "arrival_time", Message: "only applies to transit"}
+		}
+	}
+	if req.FromHeading != nil {
+		if *req.FromHeading < 0 || *req.FromHeading > 359 {
+			return ValidationError{Field: "from_heading", Message: "must be 0..359"}
+		}
+		if normalizeDirectionsMode(req.Mode) != directionsModeDrive {
+			return ValidationError{Field: "from_heading", Message: "only applies to drive"}
+		}
+		if req.FromLocation == nil {
+			return ValidationError{Field: "from_heading", Message: "requires from lat/lng"}
+		}
+	}
+	if req.InstructionFormat != "" {
+		if _, ok := instructionFormats[req.InstructionFormat]; !ok {
+			return ValidationError{Field: "instruction_format", Message: "must be plain, html, or markdown"}
+		}
+	}
+	if req.TrafficModel != "" {
+		if _, ok := directionsTrafficModels[req.TrafficModel]; !ok {
+			return ValidationError{Field: "traffic_model", Message: "must be best_guess, pessimistic, or optimistic"}
+		}
+		if normalizeDirectionsMode(req.Mode) != directionsModeDrive {
+			return ValidationError{Field: "traffic_model", Message: "only applies to drive"}
+		}
+		if req.DepartureTime == nil {
+			return ValidationError{Field: "traffic_model", Message: "requires departure_time"}
+		}
+	}
+	if len(req.Waypoints) > maxDirectionsWaypoints {
+		return ValidationError{Field: "waypoints", Message: fmt.Sprintf("at most %d allowed", maxDirectionsWaypoints)}
+	}
+	for i, waypoint := range req.Waypoints {
+		if waypoint == "" {
+			return ValidationError{Field: fmt.Sprintf("waypoints[%d]", i), Message: "empty"}
+		}
+	}
+	if req.OptimizeWaypoints && len(req.Waypoints) == 0 {
+		return ValidationError{Field: "optimize_waypoints", Message: "requires at least one waypoint"}
+	}
+	for _, feature := range req.Avoid {
+		if _, ok := directionsAvoidFeatures[feature]; !ok {
+			return ValidationError{Field: "avoid", Message: fmt.Sprintf("unknown feature %q (want tolls, highways, ferries, or indoor)", feature)}
+		}
+	}
+	for _, mode := range req.TransitModes {
+		if _, ok := directionsTransitModes[mode]; !ok {
+			return ValidationError{Field: "transit_mode", Message: fmt.Sprintf("unknown mode %q (want bus, subway, train, tram, or rail)", mode)}
+		}
+	}
+	if len(req.TransitModes) > 0 && normalizeDirectionsMode(req.Mode) != directionsModeTransit {
+		return ValidationError{Field: "transit_mode", Message: "only applies to transit"}
+	}
+	if req.TransitRoutingPreference != "" {
+		if _, ok := directionsTransitPreferences[req.TransitRoutingPreference]; !ok {
+			return ValidationError{Field: "transit_routing_preference", Message: "must be less_walking or fewer_transfers"}
+		}
+		if normalizeDirectionsMode(req.Mode) != directionsModeTransit {
+			return ValidationError{Field: "transit_routing_preference", Message: "only applies to transit"}
+		}
+	}
 	return nil
 }
 
@@ -234,11 +670,15 @@ func resolveDirectionsLocation(label string, placeID string, location *LatLng, t
 		return "place_id:" + strings.TrimSpace(placeID), nil
 	}
 	if location != nil {
-		return fmt.Sprintf("%.6f,%.6f", location.Lat, location.Lng), nil
+		return formatLatLng(*location), nil
 	}
 	return strings.TrimSpace(text), nil
 }
 
+func formatLatLng(location LatLng) string {
+	return fmt.Sprintf("%.6f,%.6f", location.Lat, location.Lng)
+}
+
 func normalizeDirectionsMode(mode string) string {
 	switch strings.ToLower(strings.TrimSpace(mode)) {
 	case "walk", "walking":
@@ -254,33 +694,194 @@ func normalizeDirectionsMode(mode string) string {
 	}
 }
 
-func buildDirectionsURL(base string, query map[string]string, apiKey string) (string, error) {
+// queryEndpoint is a pre-parsed GET base URL, so batch callers do not pay for
+// url.Parse on every request.
+type queryEndpoint struct {
+	// prefix is the URL up to and including "?" when the base has no query.
+	prefix string
+	parsed *url.URL
+	err    error
+}
+
+func newQueryEndpoint(base string) queryEndpoint {
+	parsed, err := url.Parse(base)
+	if err != nil {
+		return queryEndpoint{err: redactURLError(err)}
+	}
+	endpoint := queryEndpoint{parsed: parsed}
+	if parsed.RawQuery == "" {
+		stripped := *parsed
+		stripped.Fragment = ""
+		stripped.RawFragment = ""
+		endpoint.prefix = stripped.String() + "?"
+	}
+	return endpoint
+}
+
+func buildDirectionsURL(base queryEndpoint, query map[string]string, apiKey string, maxLength int) (string, error) {
 	if strings.TrimSpace(apiKey) == "" {
 		return "", ErrMissingAPIKey
 	}
-	parsed, err := url.Parse(base)
-	if err != nil {
-		return "", fmt.Errorf("goplaces: invalid directions url: %w", err)
+	if base.err != nil {
+		return "", fmt.Errorf("goplaces: invalid directions url: %w", base.err)
+	}
+	var endpoint string
+	if base.prefix != "" {
+		endpoint = base.prefix + encodeQuery(query, apiKey)
+	} else {
+		// Rare: the base URL carries its own query, so merge the slow way.
+		parsed := *base.parsed
+		values := parsed.Query()
+		for key, value := range query {
+			if strings.TrimSpace(value) == "" {
+				continue
+			}
+			values.Set(key, value)
+		}
+		values.Set("key", apiKey)
+		parsed.RawQuery = values.Encode()
+		endpoint = parsed.String()
 	}
-	values := parsed.Query()
+	if maxLength > 0 && len(endpoint) > maxLength {
+		return "", fmt.Errorf("%w: %d bytes exceeds %d; use fewer waypoints or the Routes API (POST)", ErrURLTooLong, len(endpoint), maxLength)
+	}
+	return endpoint, nil
+}
+
+// encodeQuery matches url.Values.Encode (sorted keys) without building the map.
+func encodeQuery(query map[string]string, apiKey string) string {
+	keys := make([]string, 0, len(query)+1)
+	size := len("key=") + len(apiKey)
 	for key, value := range query {
-		if strings.TrimSpace(value) == "" {
+		if strings.TrimSpace(value) == "" || key == "key" {
 			continue
 		}
-		values.Set(key, value)
+		keys = append(keys, key)
+		size += len(key) + len(value) + 2
 	}
-	values.Set("key", apiKey)
-	parsed.RawQuery = values.Encode()
-	return parsed.String(), nil
+	keys = append(keys, "key")
+	sort.Strings(keys)
+
+	var builder strings.Builder
+	builder.Grow(size + size/4)
+	for i, key := range keys {
+		if i > 0 {
+			builder.WriteByte('&')
+		}
+		value := apiKey
+		if key != "key" {
+			value = query[key]
+		}
+		builder.WriteString(url.QueryEscape(key))
+		builder.WriteByte('=')
+		builder.WriteString(url.QueryEscape(value))
+	}
+	return builder.String()
+}
+
+// invalidRequestFields maps parameter names Google mentions in INVALID_REQUEST
+// messages to the request fields callers set.
+var invalidRequestFields = []struct {
+	param string
+	field string
+}{
+	{"origin", "from"},
+	{"destination", "to"},
+	{"waypoints", "waypoints"},
+	{"departure_time", "departure_time"},
+	{"traffic_model", "traffic_model"},
+	{"avoid", "avoid"},
+	{"transit_routing_preference", "transit_routing_preference"},
+	{"transit_mode", "transit_mode"},
+	{"arrival_time", "arrival_time"},
+	{"latlng", "location"},
+	{"address", "address"},
+	{"mode", "mode"},
+	{"units", "units"},
+	{"language", "language"},
+	{"region", "region"},
+}
+
+// statusError converts a non-OK legacy API status into a *StatusError.
+func statusError(api string, status string, message string) error {
+	message = redactKey(strings.TrimSpace(message))
+	err := &StatusError{API: api, Status: status, Message: message}
+	switch status {
+	case "INVALID_REQUEST":
+		err.Err = &InvalidRequestError{Field: invalidRequestField(message), Message: message}
+	case "REQUEST_DENIED":
+		err.Err = &RequestDeniedError{API: legacyAPINames[api], Reason: deniedReason(message), Message: message}
+	case "ZERO_RESULTS":
+		if api == "directions" {
+			err.Err = ErrNoRoute
+		}
+	}
+	return err
 }
 
+// legacyAPINames are the Cloud Console names used in REQUEST_DENIED hints.
+var legacyAPINames = map[string]string{
+	"directions":     "Directions API",
+	"geocode":        "Geocoding API",
+	"distancematrix": "Distance Matrix API",
+}
+
+func invalidRequestField(message string) string {
+	lower := strings.ToLower(message)
+	for _, entry := range invalidRequestFields {
+		if strings.Contains(lower, entry.param) {
+			return entry.field
+		}
+	}
+	return ""
+}
+
+// doDirectionsRequest serves legacy GET calls (Directions, Geocoding) from
+// Options.Cache when possible, otherwise sends them with retries, sharing one
+// call among identical concurrent requests when Options.CoalesceRequests is set.
+// Time-dependent requests never touch the cache.
 func (c *Client) doDirectionsRequest(ctx context.Context, endpoint string) ([]byte, error) {
+	key := ""
+	if c.cache != nil || c.flights != nil {
+		key = cacheKey(endpoint)
+	}
+	cache := c.cache
+	if cache != nil && !cacheableRequest(key) {
+		cache = nil
+	}
+	if cache != nil {
+		if payload, ok := cache.Get(key); ok {
+			return payload, nil
+		}
+	}
+	fetch := func() ([]byte, error) {
+		payload, err := c.withRetries(ctx, func() ([]byte, error) {
+			return c.sendDirectionsRequest(ctx, endpoint)
+		})
+		if err == nil && cache != nil && cacheableResponse(payload) {
+			cache.Set(key, payload)
+		}
+		return payload, err
+	}
+	if c.flights != nil {
+		return c.flights.do(ctx, key, fetch)
+	}
+	return fetch()
+}
+
+// sendDirectionsRequest performs one legacy GET call, waiting on the rate
+// limiter first so retries are paced too.
+func (c *Client) sendDirectionsRequest(ctx context.Context, endpoint string) ([]byte, error) {
+	if err := c.waitForLimiter(ctx); err != nil {
+		return nil, err
+	}
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("goplaces: build directions request: %w", err)
 	}
+	request.Header.Set("User-Agent", c.userAgent)
 
-	response, err := c.httpClient.Do(request)
+	response, err := c.do(c.httpClient, request)
 	if err != nil {
 		return nil, fmt.Errorf("goplaces: directions request failed: %w", err)
 	}
@@ -288,37 +889,69 @@ func (c *Client) doDirectionsRequest(ctx context.Context, endpoint string) ([]by
 		_ = response.Body.Close()
 	}()
 
-	payload, err := readResponseBody(response)
+	payload, err := readResponseBody(response, c.maxResponseBytes)
 	if err != nil {
 		return nil, err
 	}
 
 	if response.StatusCode >= http.StatusBadRequest {
-		apiErr := &APIError{StatusCode: response.StatusCode, Body: strings.TrimSpace(string(payload))}
-		return nil, apiErr
+		return nil, newAPIError(response.StatusCode, payload)
 	}
 
 	return payload, nil
 }
 
 type directionsAPIResponse struct {
-	Status       string            `json:"status"`
-	ErrorMessage string            `json:"error_message,omitempty"`
-	Routes       []directionsRoute `json:"routes"`
+	Status            string                    `json:"status"`
+	ErrorMessage      string                    `json:"error_message,omitempty"`
+	GeocodedWaypoints []geocodedWaypointPayload `json:"geocoded_waypoints,omitempty"`
+	Routes            []directionsRoute         `json:"routes"`
+
+	// raw is the undecoded body, shared with the cache; never modify it.
+	raw []byte
+}
+
+type geocodedWaypointPayload struct {
+	GeocoderStatus string   `json:"geocoder_status,omitempty"`
+	PartialMatch   bool     `json:"partial_match,omitempty"`
+	PlaceID        string   `json:"place_id,omitempty"`
+	Types          []string `json:"types,omitempty"`
 }
 
 type directionsRoute struct {
-	Summary  string          `json:"summary,omitempty"`
-	Warnings []string        `json:"warnings,omitempty"`
-	Legs     []directionsLeg `json:"legs"`
+	Summary          string             `json:"summary,omitempty"`
+	Warnings         []string           `json:"warnings,omitempty"`
+	Legs             []directionsLeg    `json:"legs"`
+	OverviewPolyline directionsPolyline `json:"overview_polyline"`
+	WaypointOrder    []int              `json:"waypoint_order,omitempty"`
+	Bounds           *boundsPayload     `json:"bounds,omitempty"`
+	Fare             *farePayload       `json:"fare,omitempty"`
+}
+
+type farePayload struct {
+	Currency string  `json:"currency"`
+	Text     string  `json:"text"`
+	Value    float64 `json:"value"`
+}
+
+type boundsPayload struct {
+	Northeast latLngPayload `json:"northeast"`
+	Southwest latLngPayload `json:"southwest"`
+}
+
+type directionsPolyline struct {
+	Points string `json:"points,omitempty"`
 }
 
 type directionsLeg struct {
-	Distance     directionsValue  `json:"distance"`
-	Duration     directionsValue  `json:"duration"`
-	StartAddress string           `json:"start_address,omitempty"`
-	EndAddress   string           `json:"end_address,omitempty"`
-	Steps        []directionsStep `json:"steps"`
+	Distance          directionsValue  `json:"distance"`
+	Duration          directionsValue  `json:"duration"`
+	DurationInTraffic directionsValue  `json:"duration_in_traffic"`
+	StartAddress      string           `json:"start_address,omitempty"`
+	EndAddress        string           `json:"end_address,omitempty"`
+	StartLocation     *latLngPayload   `json:"start_location,omitempty"`
+	EndLocation       *latLngPayload   `json:"end_location,omitempty"`
+	Steps             []directionsStep `json:"steps"`
 }
 
 type directionsStep struct {
@@ -327,6 +960,37 @@ type directionsStep struct {
 	Duration         directionsValue `json:"duration"`
 	TravelMode       string          `json:"travel_mode,omitempty"`
 	Maneuver         string          `json:"maneuver,omitempty"`
+	// Polyline is the step's own geometry, finer than the route overview.
+	Polyline       directionsPolyline     `json:"polyline"`
+	TransitDetails *transitDetailsPayload `json:"transit_details,omitempty"`
+}
+
+type transitDetailsPayload struct {
+	ArrivalStop   transitStopPayload `json:"arrival_stop"`
+	DepartureStop transitStopPayload `json:"departure_stop"`
+	ArrivalTime   transitTimePayload `json:"arrival_time"`
+	DepartureTime transitTimePayload `json:"departure_time"`
+	Headsign      string             `json:"headsign,omitempty"`
+	NumStops      int                `json:"num_stops,omitempty"`
+	Line          transitLinePayload `json:"line"`
+}
+
+type transitStopPayload struct {
+	Name string `json:"name,omitempty"`
+}
+
+type transitTimePayload struct {
+	Text     string `json:"text,omitempty"`
+	TimeZone string `json:"time_zone,omitempty"`
+	Value    int64  `json:"value,omitempty"`
+}
+
+type transitLinePayload struct {
+	Name      string `json:"name,omitempty"`
+	ShortName string `json:"short_name,omitempty"`
+	Vehicle   struct {
+		Type string `json:"type,omitempty"`
+	} `json:"vehicle"`
 }
 
 type directionsValue struct {
@@ -334,7 +998,108 @@ type directionsValue struct {
 	Value int    `json:"value,omitempty"`
 }
 
-var htmlTagPattern = regexp.MustCompile(`<[^>]+>`)
+func mapDirectionsLeg(leg directionsLeg, instructionFormat string) DirectionsLeg {
+	steps := make([]DirectionsStep, 0, len(leg.Steps))
+	for _, step := range leg.Steps {
+		steps = append(steps, DirectionsStep{
+			Instruction:     formatInstruction(step.HTMLInstructions, instructionFormat),
+			DistanceText:    step.Distance.Text,
+			DistanceMeters:  step.Distance.Value,
+			DurationText:    step.Duration.Text,
+			DurationSeconds: step.Duration.Value,
+			TravelMode:      step.TravelMode,
+			Maneuver:        step.Maneuver,
+			Polyline:        step.Polyline.Points,
+			TransitDetails:  mapTransitDetails(step),
+		})
+	}
+	return DirectionsLeg{
+		StartAddress:             leg.StartAddress,
+		EndAddress:               leg.EndAddress,
+		StartLocation:            mapLatLngPayload(leg.StartLocation),
+		EndLocation:              mapLatLngPayload(leg.EndLocation),
+		DistanceText:             leg.Distance.Text,
+		DistanceMeters:           leg.Distance.Value,
+		DurationText:             leg.Duration.Text,
+		DurationSeconds:          leg.Duration.Value,
+		DurationInTrafficText:    leg.DurationInTraffic.Text,
+		DurationInTrafficSeconds: leg.DurationInTraffic.Value,
+		Steps:                    steps,
+	}
+}
+
+func mapTransitDetails(step directionsStep) *TransitDetails {
+	if step.TravelMode != "TRANSIT" || step.TransitDetails == nil {
+		return nil
+	}
+	details := step.TransitDetails
+	return &TransitDetails{
+		LineShortName:     details.Line.ShortName,
+		LineName:          details.Line.Name,
+		VehicleType:       details.Line.Vehicle.Type,
+		Headsign:          details.Headsign,
+		NumStops:          details.NumStops,
+		DepartureStop:     details.DepartureStop.Name,
+		ArrivalStop:       details.ArrivalStop.Name,
+		DepartureTime:     mapTransitTime(details.DepartureTime),
+		DepartureTimeText: details.DepartureTime.Text,
+		ArrivalTime:       mapTransitTime(details.ArrivalTime),
+		ArrivalTimeText:   details.ArrivalTime.Text,
+	}
+}
+
+// mapTransitTime keeps UTC when the zone is unknown to this system's tzdata.
+func mapTransitTime(value transitTimePayload) *time.Time {
+	if value.Value == 0 {
+		return nil
+	}
+	t := time.Unix(value.Value, 0).UTC()
+	if location, err := time.LoadLocation(value.TimeZone); err == nil && value.TimeZone != "" {
+		t = t.In(location)
+	}
+	return &t
+}
+
+func mapLatLngPayload(location *latLngPayload) *LatLng {
+	if location == nil {
+		return nil
+	}
+	return &LatLng{Lat: location.Lat, Lng: location.Lng}
+}
+
+func mapGeocodedWaypoints(waypoints []geocodedWaypointPayload) []GeocodedWaypoint {
+	if len(waypoints) == 0 {
+		return nil
+	}
+	mapped := make([]GeocodedWaypoint, 0, len(waypoints))
+	for _, waypoint := range waypoints {
+		mapped = append(mapped, GeocodedWaypoint{
+			Status:       waypoint.GeocoderStatus,
+			PartialMatch: waypoint.PartialMatch,
+			PlaceID:      waypoint.PlaceID,
+			Types:        waypoint.Types,
+		})
+	}
+	return mapped
+}
+
+var (
+	htmlTagPattern = regexp.MustCompile(`<[^>]+>`)
+	boldTagPattern = regexp.MustCompile(`(?i)</?b>`)
+)
+
+// formatInstruction renders html_instructions in one of the
+// DirectionsRequest.InstructionFormat styles; unknown formats are plain.
+func formatInstruction(input string, format string) string {
+	switch format {
+	case instructionFormatHTML:
+		return strings.TrimSpace(input)
+	case instructionFormatMarkdown:
+		return cleanInstruction(boldTagPattern.ReplaceAllString(input, "**"))
+	default:
+		return cleanInstruction(input)
+	}
+}
 
 func cleanInstruction(input string) string {
 	cleaned := htmlTagPattern.ReplaceAllString(input, "")
@@ -344,10 +1109,10 @@ func cleanInstruction(input string) string {
 	return cleaned
 }
 
-func readResponseBody(response *http.Response) ([]byte, error) {
-	payload, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+func readResponseBody(response *http.Response, limit int64) ([]byte, error) {
+	payload, err := readLimited(response.Body, limit)
 	if err != nil {
-		return nil, fmt.Errorf("goplaces: read response: %w", err)
+		return nil, err
 	}
 	if len(payload) == 0 {
 		return nil, errors.New("goplaces: empty response")
@@ -0,0 +1,58 @@
+package goplaces
+
+import (
+	"context"
+	"errors"
+)
+
+// defaultBorderSamples is how many points RouteCountries reverse-geocodes
+// when the caller passes 0; each one may cost a Geocoding request.
+const defaultBorderSamples = 8
+
+// RouteCountries reverse-geocodes points sampled evenly along the route's
+// overview polyline and returns the ISO country codes it passes through, in
+// order and without repeats. It is best effort: a short excursion between two
+// samples goes unnoticed, and points at sea are skipped. Lookups share the
+// client's country cache, so repeated checks of nearby routes are cheap.
+func (c *Client) RouteCountries(ctx context.Context, route DirectionsResponse, samples int) ([]string, error) {
+	if samples <= 0 {
+		samples = defaultBorderSamples
+	}
+	points, err := routePoints(route)
+	if err != nil {
+		return nil, err
+	}
+
+	var countries []string
+	for _, point := range sampleWaypoints(points, samples) {
+		country, err := c.countryForLocation(ctx, point)
+		if err != nil {
+			return nil, err
+		}
+		if country == "" || (len(countries) > 0 && countries[len(countries)-1] == country) {
+			continue
+		}
+		countries = append(countries, country)
+	}
+	return countries, nil
+}
+
+// routePoints prefers the overview polyline and falls back to leg endpoints.
+func routePoints(route DirectionsResponse) ([]LatLng, error) {
+	if route.EncodedPolyline != "" {
+		return DecodePolyline(route.EncodedPolyline)
+	}
+	var points []LatLng
+	for _, leg := range route.Legs {
+		if leg.StartLocation != nil {
+			points = append(points, *leg.StartLocation)
+		}
+		if leg.EndLocation != nil {
+			points = append(points, *leg.EndLocation)
+		}
+	}
+	if len(points) == 0 {
+		return nil, errors.New("goplaces: route has no geometry to check")
+	}
+	return points, nil
+}
@@ -0,0 +1,58 @@
+package goplaces
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// borderGeocoder answers reverse geocodes with US south of 42°N and CA north of it.
+func borderGeocoder(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lat, err := strconv.ParseFloat(strings.Split(r.URL.Query().Get("latlng"), ",")[0], 64)
+		if err != nil {
+			t.Fatalf("unexpected latlng: %q", r.URL.Query().Get("latlng"))
+		}
+		country := "US"
+		if lat > 42 {
+			country = "CA"
+		}
+		_, _ = fmt.Fprintf(w, `{"status": "OK", "results": [{"address_components": [{"short_name": %q, "types": ["country"]}]}]}`, country)
+	}))
+}
+
+func TestRouteCountries(t *testing.T) {
+	server := borderGeocoder(t)
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", GeocodeBaseURL: server.URL})
+	route := DirectionsResponse{EncodedPolyline: "_p~iF~ps|U_ulLnnqC_mqNvxq`@"}
+	countries, err := client.RouteCountries(context.Background(), route, 4)
+	if err != nil {
+		t.Fatalf("RouteCountries error: %v", err)
+	}
+	if len(countries) != 2 || countries[0] != "US" || countries[1] != "CA" {
+		t.Fatalf("unexpected countries: %v", countries)
+	}
+
+	domestic := DirectionsResponse{Legs: []DirectionsLeg{{
+		StartLocation: &LatLng{Lat: 38.5, Lng: -120.2},
+		EndLocation:   &LatLng{Lat: 40.7, Lng: -120.95},
+	}}}
+	countries, err = client.RouteCountries(context.Background(), domestic, 0)
+	if err != nil {
+		t.Fatalf("RouteCountries error: %v", err)
+	}
+	if len(countries) != 1 || countries[0] != "US" {
+		t.Fatalf("unexpected countries: %v", countries)
+	}
+
+	if _, err := client.RouteCountries(context.Background(), DirectionsResponse{}, 0); err == nil {
+		t.Fatalf("expected error for a route without geometry")
+	}
+}
@@ -0,0 +1,73 @@
+package goplaces
+
+import "strings"
+
+// DirectionsDiff is the delta between two directions responses (b minus a).
+type DirectionsDiff struct {
+	DistanceMeters  int                  `json:"distance_meters"`
+	DurationSeconds int                  `json:"duration_seconds"`
+	OnlyInA         []DirectionsStepDiff `json:"only_in_a,omitempty"`
+	OnlyInB         []DirectionsStepDiff `json:"only_in_b,omitempty"`
+}
+
+// DirectionsStepDiff is a step that appears in only one of the compared routes.
+type DirectionsStepDiff struct {
+	// Index is the zero-based position of the step in its own route.
+	Index int            `json:"index"`
+	Step  DirectionsStep `json:"step"`
+}
+
+// DiffDirections compares two routes. Steps are matched by instruction text
+// (longest common subsequence), so shared stretches are not reported.
+func DiffDirections(a, b DirectionsResponse) DirectionsDiff {
+	diff := DirectionsDiff{
+		DistanceMeters:  b.DistanceMeters - a.DistanceMeters,
+		DurationSeconds: b.DurationSeconds - a.DurationSeconds,
+	}
+
+	keysA := stepKeys(a.Steps)
+	keysB := stepKeys(b.Steps)
+	lengths := make([][]int, len(keysA)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(keysB)+1)
+	}
+	for i := len(keysA) - 1; i >= 0; i-- {
+		for j := len(keysB) - 1; j >= 0; j-- {
+			if keysA[i] == keysB[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(keysA) && j < len(keysB) {
+		switch {
+		case keysA[i] == keysB[j]:
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			diff.OnlyInA = append(diff.OnlyInA, DirectionsStepDiff{Index: i, Step: a.Steps[i]})
+			i++
+		default:
+			diff.OnlyInB = append(diff.OnlyInB, DirectionsStepDiff{Index: j, Step: b.Steps[j]})
+			j++
+		}
+	}
+	for ; i < len(keysA); i++ {
+		diff.OnlyInA = append(diff.OnlyInA, DirectionsStepDiff{Index: i, Step: a.Steps[i]})
+	}
+	for ; j < len(keysB); j++ {
+		diff.OnlyInB = append(diff.OnlyInB, DirectionsStepDiff{Index: j, Step: b.Steps[j]})
+	}
+	return diff
+}
+
+func stepKeys(steps []DirectionsStep) []string {
+	keys := make([]string, len(steps))
+	for i, step := range steps {
+		keys[i] = strings.ToLower(strings.Join(strings.Fields(step.Instruction), " "))
+	}
+	return keys
+}
@@ -0,0 +1,46 @@
+package goplaces
+
+import "testing"
+
+func TestDiffDirections(t *testing.T) {
+	a := DirectionsResponse{
+		DistanceMeters:  1000,
+		DurationSeconds: 600,
+		Steps: []DirectionsStep{
+			{Instruction: "Head north"},
+			{Instruction: "Turn left onto Toll Rd"},
+			{Instruction: "Arrive"},
+		},
+	}
+	b := DirectionsResponse{
+		DistanceMeters:  1400,
+		DurationSeconds: 900,
+		Steps: []DirectionsStep{
+			{Instruction: "head  north"},
+			{Instruction: "Turn right onto Main St"},
+			{Instruction: "Continue"},
+			{Instruction: "Arrive"},
+		},
+	}
+
+	diff := DiffDirections(a, b)
+	if diff.DistanceMeters != 400 || diff.DurationSeconds != 300 {
+		t.Fatalf("unexpected delta: %#v", diff)
+	}
+	if len(diff.OnlyInA) != 1 || diff.OnlyInA[0].Index != 1 {
+		t.Fatalf("unexpected only_in_a: %#v", diff.OnlyInA)
+	}
+	if len(diff.OnlyInB) != 2 || diff.OnlyInB[0].Index != 1 || diff.OnlyInB[1].Step.Instruction != "Continue" {
+		t.Fatalf("unexpected only_in_b: %#v", diff.OnlyInB)
+	}
+
+	same := DiffDirections(a, a)
+	if same.DistanceMeters != 0 || len(same.OnlyInA) != 0 || len(same.OnlyInB) != 0 {
+		t.Fatalf("expected empty diff, got %#v", same)
+	}
+
+	trailing := DiffDirections(a, DirectionsResponse{})
+	if len(trailing.OnlyInA) != 3 {
+		t.Fatalf("expected all steps only in a, got %#v", trailing)
+	}
+}
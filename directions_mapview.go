@@ -0,0 +1,77 @@
+package goplaces
+
+import "math"
+
+const (
+	// mapViewSizePx is the viewport MapView fits the route into: the largest
+	// Static Maps image at scale 1.
+	mapViewSizePx = 640
+	mapTileSizePx = 256
+	mapMaxZoom    = 21
+)
+
+// MapView returns the center and the largest zoom level at which the whole
+// route fits a 640x640 px web-mercator map (Static Maps, Maps JavaScript).
+// It uses Google's Bounds when present, otherwise the box around the overview
+// polyline or leg endpoints. A route without geometry returns the zero LatLng
+// and zoom 0.
+func (r DirectionsResponse) MapView() (center LatLng, zoom int) {
+	if r.Bounds != nil {
+		return fitBounds(r.Bounds.Southwest, r.Bounds.Northeast, mapViewSizePx)
+	}
+	points, err := routePoints(r)
+	if err != nil || len(points) == 0 {
+		return LatLng{}, 0
+	}
+	southwest, northeast := points[0], points[0]
+	for _, point := range points[1:] {
+		southwest.Lat = math.Min(southwest.Lat, point.Lat)
+		southwest.Lng = math.Min(southwest.Lng, point.Lng)
+		northeast.Lat = math.Max(northeast.Lat, point.Lat)
+		northeast.Lng = math.Max(northeast.Lng, point.Lng)
+	}
+	return fitBounds(southwest, northeast, mapViewSizePx)
+}
+
+// fitBounds centers on the mercator midpoint and picks the zoom whose world
+// width makes both spans fit in sizePx.
+func fitBounds(southwest, northeast LatLng, sizePx int) (LatLng, int) {
+	south, north := mercatorY(southwest.Lat), mercatorY(northeast.Lat)
+	center := LatLng{
+		Lat: inverseMercatorY((south + north) / 2),
+		Lng: (southwest.Lng + northeast.Lng) / 2,
+	}
+	latFraction := (north - south) / (2 * math.Pi)
+	lngFraction := (northeast.Lng - southwest.Lng) / 360
+	zoom := min(zoomForFraction(sizePx, latFraction), zoomForFraction(sizePx, lngFraction))
+	return center, zoom
+}
+
+func zoomForFraction(sizePx int, fraction float64) int {
+	if fraction <= 0 {
+		return mapMaxZoom
+	}
+	zoom := int(math.Floor(math.Log2(float64(sizePx) / mapTileSizePx / fraction)))
+	return max(0, min(zoom, mapMaxZoom))
+}
+
+// mercatorY projects a latitude onto the web-mercator y axis (radians),
+// clamped to the square world Google draws.
+func mercatorY(lat float64) float64 {
+	sin := math.Sin(lat * math.Pi / 180)
+	y := math.Log((1+sin)/(1-sin)) / 2
+	return math.Max(math.Min(y, math.Pi), -math.Pi)
+}
+
+func inverseMercatorY(y float64) float64 {
+	return math.Atan(math.Sinh(y)) * 180 / math.Pi
+}
+
+// StepPath decodes the step's polyline. Steps without geometry (for example
+// responses from the Routes-backed route command) return an empty path.
+func (s DirectionsStep) StepPath() ([]LatLng, error) {
+	if s.Polyline == "" {
+		return []LatLng{}, nil
+	}
+	return DecodePolyline(s.Polyline)
+}
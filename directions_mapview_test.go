@@ -0,0 +1,100 @@
+package goplaces
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDirectionsMapView(t *testing.T) {
+	route := DirectionsResponse{EncodedPolyline: "_p~iF~ps|U_ulLnnqC_mqNvxq`@"}
+	center, zoom := route.MapView()
+	if zoom != 7 {
+		t.Fatalf("expected zoom 7, got %d", zoom)
+	}
+	if math.Abs(center.Lat-40.9187) > 1e-3 || math.Abs(center.Lng+123.3265) > 1e-9 {
+		t.Fatalf("unexpected center: %#v", center)
+	}
+
+	// A short city route fits at street level; longitude is the tighter span.
+	city := DirectionsResponse{Legs: []DirectionsLeg{{
+		StartLocation: &LatLng{Lat: 52.5, Lng: 13.3},
+		EndLocation:   &LatLng{Lat: 52.52, Lng: 13.42},
+	}}}
+	if _, zoom := city.MapView(); zoom != 12 {
+		t.Fatalf("expected zoom 12, got %d", zoom)
+	}
+
+	point := DirectionsResponse{Legs: []DirectionsLeg{{StartLocation: &LatLng{Lat: 1, Lng: 2}}}}
+	if center, zoom := point.MapView(); zoom != mapMaxZoom || math.Abs(center.Lat-1) > 1e-9 || center.Lng != 2 {
+		t.Fatalf("unexpected single-point view: %#v %d", center, zoom)
+	}
+
+	if center, zoom := (DirectionsResponse{}).MapView(); zoom != 0 || center != (LatLng{}) {
+		t.Fatalf("expected zero view, got %#v %d", center, zoom)
+	}
+
+	// Google's bounds win over the geometry.
+	city.Bounds = &LatLngBounds{Northeast: LatLng{Lat: 52.6, Lng: 13.8}, Southwest: LatLng{Lat: 52.4, Lng: 13.0}}
+	if center, zoom := city.MapView(); zoom != 10 || math.Abs(center.Lng-13.4) > 1e-9 {
+		t.Fatalf("expected the bounds view, got %#v %d", center, zoom)
+	}
+}
+
+func TestDirectionsBounds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{
+			"bounds": {"northeast": {"lat": 47.6205, "lng": -122.3212}, "southwest": {"lat": 47.6062, "lng": -122.3493}},
+			"legs": [{"distance": {"value": 1}, "duration": {"value": 1}}]
+		}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	response, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B"})
+	if err != nil {
+		t.Fatalf("directions: %v", err)
+	}
+	want := LatLngBounds{Northeast: LatLng{Lat: 47.6205, Lng: -122.3212}, Southwest: LatLng{Lat: 47.6062, Lng: -122.3493}}
+	if response.Bounds == nil || *response.Bounds != want {
+		t.Fatalf("unexpected bounds: %#v", response.Bounds)
+	}
+
+	merged := MergeDirections(response, DirectionsResponse{}, DirectionsResponse{
+		Bounds: &LatLngBounds{Northeast: LatLng{Lat: 47.7, Lng: -122.33}, Southwest: LatLng{Lat: 47.61, Lng: -122.4}},
+	})
+	union := LatLngBounds{Northeast: LatLng{Lat: 47.7, Lng: -122.3212}, Southwest: LatLng{Lat: 47.6062, Lng: -122.4}}
+	if merged.Bounds == nil || *merged.Bounds != union {
+		t.Fatalf("unexpected merged bounds: %#v", merged.Bounds)
+	}
+}
+
+func TestDirectionsStepPath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{"steps": [
+			{"html_instructions": "Head north", "polyline": {"points": "_p~iF~ps|U_ulLnnqC"}},
+			{"html_instructions": "Arrive"}
+		]}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	response, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B"})
+	if err != nil {
+		t.Fatalf("Directions error: %v", err)
+	}
+	path, err := response.Steps[0].StepPath()
+	if err != nil {
+		t.Fatalf("StepPath error: %v", err)
+	}
+	if len(path) != 2 || path[1].Lat != 40.7 || path[1].Lng != -120.95 {
+		t.Fatalf("unexpected path: %#v", path)
+	}
+
+	path, err = response.Steps[1].StepPath()
+	if err != nil || path == nil || len(path) != 0 {
+		t.Fatalf("expected empty path for a step without geometry, got %#v, %v", path, err)
+	}
+}
@@ -0,0 +1,127 @@
+package goplaces
+
+import (
+	"fmt"
+	"strings"
+)
+
+// mergeGapWarnMeters is how far apart consecutive legs may start and end
+// before MergeDirections warns; transfers within a station stay under it.
+const mergeGapWarnMeters = 250.0
+
+// MergeDirections stitches separately computed routes (e.g. bike then transit)
+// into one trip: legs, steps, warnings, and geocoded waypoints are
+// concatenated, distances and durations summed, and Bounds grown to cover
+// every route. Mode joins the distinct modes with "+". Google's localized
+// texts and the overview polyline cannot be combined and are left empty. A leg
+// that ends more than 250 m from where the next one starts adds a warning.
+func MergeDirections(responses ...DirectionsResponse) DirectionsResponse {
+	if len(responses) == 0 {
+		return DirectionsResponse{}
+	}
+	first := responses[0]
+	last := responses[len(responses)-1]
+	merged := DirectionsResponse{
+		StartAddress: first.StartAddress,
+		EndAddress:   last.EndAddress,
+		Units:        first.Units,
+		Status:       first.Status,
+	}
+
+	var modes, summaries []string
+	hasTraffic := false
+	trafficSeconds := 0
+	for i, response := range responses {
+		modes = append(modes, response.Mode)
+		summaries = append(summaries, response.Summary)
+		merged.DistanceMeters += response.TotalDistanceMeters()
+		merged.DurationSeconds += response.TotalDurationSeconds()
+		if response.DurationInTrafficSeconds > 0 {
+			hasTraffic = true
+			trafficSeconds += response.DurationInTrafficSeconds
+		} else {
+			trafficSeconds += response.TotalDurationSeconds()
+		}
+		merged.Steps = append(merged.Steps, response.Steps...)
+		merged.Legs = append(merged.Legs, responseLegs(response)...)
+		merged.Warnings = append(merged.Warnings, response.Warnings...)
+		merged.GeocodedWaypoints = append(merged.GeocodedWaypoints, response.GeocodedWaypoints...)
+		merged.Bounds = unionBounds(merged.Bounds, response.Bounds)
+		if i > 0 {
+			if warning := mergeGapWarning(responses[i-1], response, i); warning != "" {
+				merged.Warnings = append(merged.Warnings, warning)
+			}
+		}
+	}
+	merged.Mode = strings.Join(uniqueNonEmpty(modes), "+")
+	merged.Summary = strings.Join(uniqueNonEmpty(summaries), " / ")
+	if hasTraffic {
+		merged.DurationInTrafficSeconds = trafficSeconds
+	}
+	return merged
+}
+
+// unionBounds returns the smallest box containing both; either may be nil.
+func unionBounds(a, b *LatLngBounds) *LatLngBounds {
+	if a == nil || b == nil {
+		if a == nil {
+			return b
+		}
+		return a
+	}
+	return &LatLngBounds{
+		Northeast: LatLng{Lat: max(a.Northeast.Lat, b.Northeast.Lat), Lng: max(a.Northeast.Lng, b.Northeast.Lng)},
+		Southwest: LatLng{Lat: min(a.Southwest.Lat, b.Southwest.Lat), Lng: min(a.Southwest.Lng, b.Southwest.Lng)},
+	}
+}
+
+// responseLegs returns Legs, or a single leg built from the top-level fields
+// for responses that predate Legs.
+func responseLegs(response DirectionsResponse) []DirectionsLeg {
+	if len(response.Legs) > 0 {
+		return response.Legs
+	}
+	return []DirectionsLeg{{
+		StartAddress:             response.StartAddress,
+		EndAddress:               response.EndAddress,
+		DistanceText:             response.DistanceText,
+		DistanceMeters:           response.DistanceMeters,
+		DurationText:             response.DurationText,
+		DurationSeconds:          response.DurationSeconds,
+		DurationInTrafficText:    response.DurationInTrafficText,
+		DurationInTrafficSeconds: response.DurationInTrafficSeconds,
+		Steps:                    response.Steps,
+	}}
+}
+
+func mergeGapWarning(previous, next DirectionsResponse, index int) string {
+	prevLegs := responseLegs(previous)
+	nextLegs := responseLegs(next)
+	end := prevLegs[len(prevLegs)-1].EndLocation
+	start := nextLegs[0].StartLocation
+	if end == nil || start == nil {
+		return ""
+	}
+	gap := distanceMeters(*end, *start)
+	if gap <= mergeGapWarnMeters {
+		return ""
+	}
+	return fmt.Sprintf("gap of %.0f m between route %d end and route %d start", gap, index, index+1)
+}
+
+func uniqueNonEmpty(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		result = append(result, value)
+	}
+	return result
+}
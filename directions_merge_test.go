@@ -0,0 +1,70 @@
+package goplaces
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMergeDirections(t *testing.T) {
+	bike := DirectionsResponse{
+		Mode:            "BICYCLING",
+		Summary:         "Dexter Ave",
+		StartAddress:    "Home",
+		EndAddress:      "Station",
+		DistanceMeters:  3000,
+		DurationSeconds: 720,
+		Steps:           []DirectionsStep{{Instruction: "Ride north"}},
+		Legs: []DirectionsLeg{{
+			StartAddress:    "Home",
+			EndAddress:      "Station",
+			EndLocation:     &LatLng{Lat: 47.6, Lng: -122.3},
+			DistanceMeters:  3000,
+			DurationSeconds: 720,
+		}},
+		Units: "metric",
+	}
+	transit := DirectionsResponse{
+		Mode:                     "TRANSIT",
+		StartAddress:             "Station",
+		EndAddress:               "Office",
+		DistanceMeters:           9000,
+		DurationSeconds:          1500,
+		DurationInTrafficSeconds: 1600,
+		Steps:                    []DirectionsStep{{Instruction: "Take Line 1"}},
+		Warnings:                 []string{"Transit schedules may change"},
+	}
+
+	merged := MergeDirections(bike, transit)
+	if merged.Mode != "BICYCLING+TRANSIT" || merged.Summary != "Dexter Ave" {
+		t.Fatalf("unexpected mode/summary: %q / %q", merged.Mode, merged.Summary)
+	}
+	if merged.StartAddress != "Home" || merged.EndAddress != "Office" {
+		t.Fatalf("unexpected endpoints: %#v", merged)
+	}
+	if merged.DistanceMeters != 12000 || merged.DurationSeconds != 2220 || merged.DurationInTrafficSeconds != 2320 {
+		t.Fatalf("unexpected totals: %d m, %d s, %d s in traffic", merged.DistanceMeters, merged.DurationSeconds, merged.DurationInTrafficSeconds)
+	}
+	if len(merged.Steps) != 2 || len(merged.Legs) != 2 || merged.Legs[1].EndAddress != "Office" {
+		t.Fatalf("expected concatenated steps and legs: %#v", merged)
+	}
+	if len(merged.Warnings) != 1 || merged.Units != "metric" {
+		t.Fatalf("unexpected warnings/units: %#v", merged)
+	}
+}
+
+func TestMergeDirectionsGapWarning(t *testing.T) {
+	a := DirectionsResponse{Legs: []DirectionsLeg{{EndLocation: &LatLng{Lat: 47.6, Lng: -122.3}}}}
+	near := DirectionsResponse{Legs: []DirectionsLeg{{StartLocation: &LatLng{Lat: 47.6005, Lng: -122.3}}}}
+	far := DirectionsResponse{Legs: []DirectionsLeg{{StartLocation: &LatLng{Lat: 47.61, Lng: -122.3}}}}
+
+	if merged := MergeDirections(a, near); len(merged.Warnings) != 0 {
+		t.Fatalf("expected no warning for a short transfer, got %#v", merged.Warnings)
+	}
+	merged := MergeDirections(a, far)
+	if len(merged.Warnings) != 1 || !strings.Contains(merged.Warnings[0], "gap of 1112 m between route 1 end and route 2 start") {
+		t.Fatalf("expected gap warning, got %#v", merged.Warnings)
+	}
+	if empty := MergeDirections(); empty.Mode != "" || empty.Steps != nil {
+		t.Fatalf("expected zero response, got %#v", empty)
+	}
+}
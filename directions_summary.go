@@ -0,0 +1,90 @@
+package goplaces
+
+import (
+	"sort"
+	"strings"
+)
+
+// DirectionsResult is a set of routes for the same trip (alternatives or compared modes).
+type DirectionsResult []DirectionsResponse
+
+// RouteSummary is one row of a route comparison table.
+type RouteSummary struct {
+	// Index is the route's position in the DirectionsResult.
+	Index                    int    `json:"index"`
+	Label                    string `json:"label"`
+	DistanceMeters           int    `json:"distance_meters"`
+	DistanceText             string `json:"distance_text,omitempty"`
+	DurationSeconds          int    `json:"duration_seconds"`
+	DurationText             string `json:"duration_text,omitempty"`
+	DurationInTrafficSeconds int    `json:"duration_in_traffic_seconds,omitempty"`
+	DurationInTrafficText    string `json:"duration_in_traffic_text,omitempty"`
+}
+
+// Summaries returns one row per route, fastest first. Routes with traffic data
+// sort by their in-traffic duration; ties keep result order.
+func (r DirectionsResult) Summaries() []RouteSummary {
+	summaries := make([]RouteSummary, 0, len(r))
+	for i, route := range r {
+		label := strings.TrimSpace(route.Summary)
+		if label == "" {
+			label = route.Mode
+		}
+		summaries = append(summaries, RouteSummary{
+			Index:                    i,
+			Label:                    label,
+			DistanceMeters:           route.DistanceMeters,
+			DistanceText:             route.DistanceText,
+			DurationSeconds:          route.DurationSeconds,
+			DurationText:             route.DurationText,
+			DurationInTrafficSeconds: route.DurationInTrafficSeconds,
+			DurationInTrafficText:    route.DurationInTrafficText,
+		})
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].effectiveDuration() < summaries[j].effectiveDuration()
+	})
+	return summaries
+}
+
+// TotalDistanceMeters sums the leg distances. Google rounds every distance to
+// whole meters, so totals come from legs rather than from (many more) steps.
+func (r DirectionsResponse) TotalDistanceMeters() int {
+	if len(r.Legs) == 0 {
+		return r.DistanceMeters
+	}
+	total := 0
+	for _, leg := range r.Legs {
+		total += leg.DistanceMeters
+	}
+	return total
+}
+
+// TotalDurationSeconds sums the leg durations, like TotalDistanceMeters.
+func (r DirectionsResponse) TotalDurationSeconds() int {
+	if len(r.Legs) == 0 {
+		return r.DurationSeconds
+	}
+	total := 0
+	for _, leg := range r.Legs {
+		total += leg.DurationSeconds
+	}
+	return total
+}
+
+// OriginDrift returns the distance in meters from origin to where the route
+// actually starts, or -1 when the response has no start location. A large
+// drift usually means swapped lat/lng or a bad geocode.
+func (r DirectionsResponse) OriginDrift(origin LatLng) float64 {
+	if len(r.Legs) == 0 || r.Legs[0].StartLocation == nil {
+		return -1
+	}
+	return distanceMeters(origin, *r.Legs[0].StartLocation)
+}
+
+func (s RouteSummary) effectiveDuration() int {
+	if s.DurationInTrafficSeconds > 0 {
+		return s.DurationInTrafficSeconds
+	}
+	return s.DurationSeconds
+}
@@ -0,0 +1,57 @@
+package goplaces
+
+import "testing"
+
+func TestDirectionsResultSummaries(t *testing.T) {
+	result := DirectionsResult{
+		{Mode: "DRIVING", Summary: "I-5", DistanceMeters: 5000, DurationSeconds: 600, DurationInTrafficSeconds: 1500, DurationInTrafficText: "25 mins"},
+		{Mode: "DRIVING", Summary: " ", DistanceMeters: 7000, DurationSeconds: 900},
+		{Mode: "WALKING", DistanceMeters: 4000, DurationSeconds: 3000},
+	}
+
+	summaries := result.Summaries()
+	if len(summaries) != 3 {
+		t.Fatalf("unexpected summaries: %#v", summaries)
+	}
+	if summaries[0].Index != 1 || summaries[0].Label != "DRIVING" {
+		t.Fatalf("expected the traffic-free drive first, got %#v", summaries[0])
+	}
+	if summaries[1].Index != 0 || summaries[1].Label != "I-5" || summaries[1].DurationInTrafficText != "25 mins" {
+		t.Fatalf("expected I-5 second (traffic-adjusted), got %#v", summaries[1])
+	}
+	if summaries[2].Index != 2 {
+		t.Fatalf("expected walk last, got %#v", summaries[2])
+	}
+}
+
+func TestDirectionsResponseTotals(t *testing.T) {
+	response := DirectionsResponse{
+		DistanceMeters:  1000,
+		DurationSeconds: 300,
+		Legs: []DirectionsLeg{
+			{DistanceMeters: 1000, DurationSeconds: 300, Steps: []DirectionsStep{{DistanceMeters: 499}, {DistanceMeters: 499}}},
+			{DistanceMeters: 2001, DurationSeconds: 540},
+		},
+	}
+	if got := response.TotalDistanceMeters(); got != 3001 {
+		t.Fatalf("unexpected total distance: %d", got)
+	}
+	if got := response.TotalDurationSeconds(); got != 840 {
+		t.Fatalf("unexpected total duration: %d", got)
+	}
+
+	flat := DirectionsResponse{DistanceMeters: 1200, DurationSeconds: 600}
+	if flat.TotalDistanceMeters() != 1200 || flat.TotalDurationSeconds() != 600 {
+		t.Fatalf("expected top-level fallback, got %d/%d", flat.TotalDistanceMeters(), flat.TotalDurationSeconds())
+	}
+}
+
+func TestOriginDrift(t *testing.T) {
+	response := DirectionsResponse{Legs: []DirectionsLeg{{StartLocation: &LatLng{Lat: 47.61, Lng: -122.3}}}}
+	if drift := response.OriginDrift(LatLng{Lat: 47.6, Lng: -122.3}); drift < 1100 || drift > 1125 {
+		t.Fatalf("unexpected drift: %f", drift)
+	}
+	if drift := (DirectionsResponse{}).OriginDrift(LatLng{}); drift != -1 {
+		t.Fatalf("expected -1 without a start location, got %f", drift)
+	}
+}
@@ -2,9 +2,14 @@ package goplaces
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestDirectionsRequestPlaceID(t *testing.T) {
@@ -27,9 +32,11 @@ func TestDirectionsRequestPlaceID(t *testing.T) {
 		}
 		_, _ = w.Write([]byte(`{
 			"status": "OK",
+			"geocoded_waypoints": [{"geocoder_status": "OK", "partial_match": true, "place_id": "from", "types": ["locality"]}],
 			"routes": [{
 				"summary": "Main",
 				"warnings": ["test"],
+				"overview_polyline": {"points": "_p~iF~ps|U"},
 				"legs": [{
 					"distance": {"text": "1 km", "value": 1000},
 					"duration": {"text": "10 mins", "value": 600},
@@ -65,6 +72,15 @@ func TestDirectionsRequestPlaceID(t *testing.T) {
 	if response.Mode != "WALKING" {
 		t.Fatalf("unexpected mode: %s", response.Mode)
 	}
+	if response.Status != "OK" {
+		t.Fatalf("unexpected status: %s", response.Status)
+	}
+	if response.EncodedPolyline != "_p~iF~ps|U" {
+		t.Fatalf("unexpected polyline: %s", response.EncodedPolyline)
+	}
+	if len(response.GeocodedWaypoints) != 1 || !response.GeocodedWaypoints[0].PartialMatch || response.GeocodedWaypoints[0].Types[0] != "locality" {
+		t.Fatalf("unexpected geocoded waypoints: %#v", response.GeocodedWaypoints)
+	}
 }
 
 func TestDirectionsModeValidation(t *testing.T) {
@@ -84,9 +100,770 @@ func TestDirectionsUnitsValidation(t *testing.T) {
 	}
 }
 
+func TestDirectionsTrafficModelValidation(t *testing.T) {
+	departure := time.Unix(1700000000, 0)
+	cases := []struct {
+		name string
+		req  DirectionsRequest
+		want string
+	}{
+		{"unknown", DirectionsRequest{Mode: "drive", TrafficModel: "psychic", DepartureTime: &departure}, "must be best_guess"},
+		{"no departure", DirectionsRequest{Mode: "drive", TrafficModel: "pessimistic"}, "requires departure_time"},
+		{"transit", DirectionsRequest{Mode: "transit", TrafficModel: "optimistic", DepartureTime: &departure}, "only applies to drive"},
+	}
+	for _, tc := range cases {
+		tc.req.From, tc.req.To = "A", "B"
+		err := validateDirectionsRequest(applyDirectionsDefaults(tc.req))
+		var validation ValidationError
+		if !errors.As(err, &validation) || validation.Field != "traffic_model" || !strings.Contains(validation.Message, tc.want) {
+			t.Fatalf("%s: unexpected error %v", tc.name, err)
+		}
+	}
+
+	ok := DirectionsRequest{From: "A", To: "B", Mode: "drive", TrafficModel: " Best_Guess ", DepartureTime: &departure}
+	if err := validateDirectionsRequest(applyDirectionsDefaults(ok)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDirectionsTrafficModelQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("traffic_model"); got != "pessimistic" {
+			t.Fatalf("unexpected traffic_model: %q", got)
+		}
+		_, _ = w.Write([]byte(`{"status":"OK","routes":[{"legs":[{"distance":{"value":1000},"duration":{"value":60}}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	departure := time.Unix(1700000000, 0)
+	_, err := client.Directions(context.Background(), DirectionsRequest{
+		From: "A", To: "B", Mode: "drive", TrafficModel: "pessimistic", DepartureTime: &departure,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDirectionsDepartureAndArrivalTime(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`{"status":"OK","routes":[{"legs":[{"distance":{"value":1000},"duration":{"value":60}}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	// Past departures are passed through; Google treats them like "now".
+	departure := time.Unix(1700000000, 0)
+	if _, err := client.Directions(context.Background(), DirectionsRequest{
+		From: "A", To: "B", Mode: "drive", DepartureTime: &departure,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := query.Get("departure_time"); got != "1700000000" {
+		t.Fatalf("unexpected departure_time: %q", got)
+	}
+
+	arrival := time.Unix(1700003600, 0)
+	if _, err := client.Directions(context.Background(), DirectionsRequest{
+		From: "A", To: "B", Mode: "transit", ArrivalTime: &arrival,
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := query.Get("arrival_time"); got != "1700003600" || query.Has("departure_time") {
+		t.Fatalf("unexpected query: %v", query)
+	}
+
+	cases := []DirectionsRequest{
+		{From: "A", To: "B", Mode: "transit", DepartureTime: &departure, ArrivalTime: &arrival},
+		{From: "A", To: "B", Mode: "drive", ArrivalTime: &arrival},
+	}
+	for _, req := range cases {
+		_, err := client.Directions(context.Background(), req)
+		var validation ValidationError
+		if !errors.As(err, &validation) || validation.Field != "arrival_time" {
+			t.Fatalf("expected arrival_time validation error, got %v", err)
+		}
+	}
+}
+
+func TestDirectionsAvoid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.RawQuery, "avoid=tolls%7Chighways") {
+			t.Fatalf("unexpected query: %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"status":"OK","routes":[{"legs":[{"distance":{"value":1000},"duration":{"value":60}}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	_, err := client.Directions(context.Background(), DirectionsRequest{
+		From: "A", To: "B", Mode: "drive", Avoid: []string{"Tolls", " highways"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B", Avoid: []string{"hills"}})
+	var validation ValidationError
+	if !errors.As(err, &validation) || validation.Field != "avoid" {
+		t.Fatalf("expected avoid validation error, got %v", err)
+	}
+}
+
+func TestDirectionsTransitPreferences(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("transit_mode"); got != "subway|tram" {
+			t.Fatalf("unexpected transit_mode: %q", got)
+		}
+		if got := r.URL.Query().Get("transit_routing_preference"); got != "less_walking" {
+			t.Fatalf("unexpected transit_routing_preference: %q", got)
+		}
+		_, _ = w.Write([]byte(`{"status":"OK","routes":[{"legs":[{"distance":{"value":1000},"duration":{"value":60}}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	_, err := client.Directions(context.Background(), DirectionsRequest{
+		From: "A", To: "B", Mode: "transit", TransitModes: []string{"Subway", "tram"}, TransitRoutingPreference: "LESS_WALKING",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		req   DirectionsRequest
+		field string
+	}{
+		{DirectionsRequest{From: "A", To: "B", Mode: "drive", TransitModes: []string{"bus"}}, "transit_mode"},
+		{DirectionsRequest{From: "A", To: "B", Mode: "transit", TransitModes: []string{"ferry"}}, "transit_mode"},
+		{DirectionsRequest{From: "A", To: "B", Mode: "walk", TransitRoutingPreference: "less_walking"}, "transit_routing_preference"},
+		{DirectionsRequest{From: "A", To: "B", Mode: "transit", TransitRoutingPreference: "fastest"}, "transit_routing_preference"},
+	}
+	for _, tc := range cases {
+		_, err := client.Directions(context.Background(), tc.req)
+		var validation ValidationError
+		if !errors.As(err, &validation) || validation.Field != tc.field {
+			t.Fatalf("expected %s validation error, got %v", tc.field, err)
+		}
+	}
+}
+
+func TestDirectionsFromHeading(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("origin"); got != "heading=90:52.520000,13.405000" {
+			t.Fatalf("unexpected origin: %q", got)
+		}
+		_, _ = w.Write([]byte(`{"status":"OK","routes":[{"legs":[{"distance":{"value":1000},"duration":{"value":60}}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	heading := 90
+	origin := &LatLng{Lat: 52.52, Lng: 13.405}
+	_, err := client.Directions(context.Background(), DirectionsRequest{FromLocation: origin, To: "B", Mode: "drive", FromHeading: &heading})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	outOfRange := 360
+	cases := []struct {
+		req  DirectionsRequest
+		want string
+	}{
+		{DirectionsRequest{FromLocation: origin, To: "B", Mode: "drive", FromHeading: &outOfRange}, "must be 0..359"},
+		{DirectionsRequest{FromLocation: origin, To: "B", Mode: "walk", FromHeading: &heading}, "only applies to drive"},
+		{DirectionsRequest{From: "A", To: "B", Mode: "drive", FromHeading: &heading}, "requires from lat/lng"},
+	}
+	for _, tc := range cases {
+		_, err := client.Directions(context.Background(), tc.req)
+		var validation ValidationError
+		if !errors.As(err, &validation) || validation.Field != "from_heading" || validation.Message != tc.want {
+			t.Fatalf("expected from_heading %q, got %v", tc.want, err)
+		}
+	}
+}
+
+func TestDirectionsModeDefaults(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		_, _ = w.Write([]byte(`{"status":"OK","routes":[{"legs":[{"distance":{"value":1000},"duration":{"value":60}}]}]}`))
+	}))
+	defer server.Close()
+
+	now := time.Unix(1700000000, 0)
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL, Clock: func() time.Time { return now }})
+	departure := time.Unix(1700007200, 0)
+	arrival := time.Unix(1700003600, 0)
+
+	cases := []struct {
+		name          string
+		req           DirectionsRequest
+		departureTime string
+		trafficModel  string
+	}{
+		{"transit departs now", DirectionsRequest{Mode: "transit"}, "1700000000", ""},
+		{"transit arrival kept", DirectionsRequest{Mode: "transit", ArrivalTime: &arrival}, "", ""},
+		{"timed drive is traffic-aware", DirectionsRequest{Mode: "drive", DepartureTime: &departure}, "1700007200", "best_guess"},
+		{"explicit traffic model kept", DirectionsRequest{Mode: "drive", DepartureTime: &departure, TrafficModel: "optimistic"}, "1700007200", "optimistic"},
+		{"untimed drive unchanged", DirectionsRequest{Mode: "drive"}, "", ""},
+		{"opt out", DirectionsRequest{Mode: "transit", NoModeDefaults: true}, "", ""},
+	}
+	for _, tc := range cases {
+		tc.req.From, tc.req.To = "A", "B"
+		if _, err := client.Directions(context.Background(), tc.req); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if got := query.Get("departure_time"); got != tc.departureTime {
+			t.Fatalf("%s: departure_time = %q, want %q", tc.name, got, tc.departureTime)
+		}
+		if got := query.Get("traffic_model"); got != tc.trafficModel {
+			t.Fatalf("%s: traffic_model = %q, want %q", tc.name, got, tc.trafficModel)
+		}
+	}
+}
+
+func TestDirectionsOptimizeWaypoints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("waypoints"); got != "optimize:true|Bakery|place_id:abc|47.6,-122.3" {
+			t.Fatalf("unexpected waypoints: %q", got)
+		}
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"waypoint_order": [2, 0, 1], "legs": [
+			{"distance": {"value": 1}, "duration": {"value": 1}},
+			{"distance": {"value": 2}, "duration": {"value": 2}},
+			{"distance": {"value": 3}, "duration": {"value": 3}},
+			{"distance": {"value": 4}, "duration": {"value": 4}}
+		]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	response, err := client.Directions(context.Background(), DirectionsRequest{
+		From:              "Home",
+		To:                "Office",
+		Mode:              "drive",
+		Waypoints:         []string{" Bakery ", "place_id:abc", "47.6,-122.3"},
+		OptimizeWaypoints: true,
+	})
+	if err != nil {
+		t.Fatalf("Directions error: %v", err)
+	}
+	if len(response.WaypointOrder) != 3 || response.WaypointOrder[0] != 2 || response.WaypointOrder[2] != 1 {
+		t.Fatalf("unexpected waypoint order: %v", response.WaypointOrder)
+	}
+	if len(response.Legs) != 4 {
+		t.Fatalf("expected one leg per hop, got %d", len(response.Legs))
+	}
+}
+
+func TestDirectionsAllAlternatives(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("alternatives"); got != "true" {
+			t.Fatalf("unexpected alternatives: %q", got)
+		}
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [
+			{"summary": "I-5 N", "legs": [{"distance": {"value": 12000}, "duration": {"value": 900},
+				"steps": [{"html_instructions": "Take I-5"}]}]},
+			{"summary": "WA-99 N", "warnings": ["Toll road"], "legs": [{"distance": {"value": 10000}, "duration": {"value": 1100},
+				"steps": [{"html_instructions": "Take WA-99"}, {"html_instructions": "Arrive"}]}]}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	req := DirectionsRequest{From: "A", To: "B", Mode: "drive", Alternatives: true}
+	routes, err := client.DirectionsAll(context.Background(), req)
+	if err != nil {
+		t.Fatalf("DirectionsAll error: %v", err)
+	}
+	if len(routes) != 2 {
+		t.Fatalf("expected 2 routes, got %d", len(routes))
+	}
+	second := routes[1]
+	if second.Summary != "WA-99 N" || second.DistanceMeters != 10000 || second.DurationSeconds != 1100 || len(second.Steps) != 2 {
+		t.Fatalf("unexpected second route: %#v", second)
+	}
+
+	first, err := client.Directions(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Directions error: %v", err)
+	}
+	if first.Summary != "I-5 N" || len(first.Steps) != 1 {
+		t.Fatalf("Directions should return the first route, got %#v", first)
+	}
+
+	strict := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL, WarningsAreErrors: true})
+	routes, err = strict.DirectionsAll(context.Background(), req)
+	if err != nil {
+		t.Fatalf("DirectionsAll error: %v", err)
+	}
+	if len(routes) != 1 || routes[0].Summary != "I-5 N" {
+		t.Fatalf("expected the warned route to be dropped, got %#v", routes)
+	}
+}
+
+func TestDirectionsWaypointValidation(t *testing.T) {
+	cases := []struct {
+		req   DirectionsRequest
+		field string
+	}{
+		{DirectionsRequest{From: "A", To: "B", OptimizeWaypoints: true}, "optimize_waypoints"},
+		{DirectionsRequest{From: "A", To: "B", Waypoints: []string{"C", " "}}, "waypoints[1]"},
+		{DirectionsRequest{From: "A", To: "B", Waypoints: make([]string, 26)}, "waypoints"},
+	}
+	for _, tc := range cases {
+		err := validateDirectionsRequest(applyDirectionsDefaults(tc.req))
+		var validation ValidationError
+		if !errors.As(err, &validation) || validation.Field != tc.field {
+			t.Fatalf("expected %s validation error, got %v", tc.field, err)
+		}
+	}
+}
+
 func TestDirectionsLocationValidation(t *testing.T) {
 	req := DirectionsRequest{FromPlaceID: "a", From: "b", To: "c"}
 	if err := validateDirectionsRequest(applyDirectionsDefaults(req)); err == nil {
 		t.Fatalf("expected validation error for multiple origin inputs")
 	}
 }
+
+func TestDirectionsURLTooLong(t *testing.T) {
+	client := NewClient(Options{APIKey: "test-key", MaxURLLength: 64})
+	_, err := client.Directions(context.Background(), DirectionsRequest{
+		From: strings.Repeat("a", 80),
+		To:   "B",
+	})
+	if !errors.Is(err, ErrURLTooLong) {
+		t.Fatalf("expected ErrURLTooLong, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "fewer waypoints") {
+		t.Fatalf("expected actionable hint, got %v", err)
+	}
+}
+
+func TestDirectionsWarningsAreErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"warnings": ["May cross borders"], "legs": [{"steps": []}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL, WarningsAreErrors: true})
+	_, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B"})
+	if !errors.Is(err, ErrRouteWarning) {
+		t.Fatalf("expected ErrRouteWarning, got %v", err)
+	}
+	var warningErr *RouteWarningError
+	if !errors.As(err, &warningErr) || len(warningErr.Warnings) != 1 || warningErr.Warnings[0] != "May cross borders" {
+		t.Fatalf("unexpected warning error: %#v", err)
+	}
+
+	client = NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	response, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B"})
+	if err != nil {
+		t.Fatalf("Directions error: %v", err)
+	}
+	if len(response.Warnings) != 1 {
+		t.Fatalf("expected warnings on response, got %#v", response.Warnings)
+	}
+}
+
+func TestDirectionsInvalidRequest(t *testing.T) {
+	message := "Invalid request. Missing the 'origin' parameter."
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "INVALID_REQUEST", "error_message": "` + message + `"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	_, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B"})
+	if !errors.Is(err, ErrInvalidRequest) {
+		t.Fatalf("expected ErrInvalidRequest, got %v", err)
+	}
+	var validation ValidationError
+	if !errors.As(err, &validation) || validation.Field != "from" || validation.Message != message {
+		t.Fatalf("expected from field hint, got %#v", err)
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	err := statusError("directions", "INVALID_REQUEST", "something odd")
+	var validation ValidationError
+	if !errors.Is(err, ErrInvalidRequest) || errors.As(err, &validation) {
+		t.Fatalf("expected bare ErrInvalidRequest, got %#v", err)
+	}
+	if err.Error() != "goplaces: invalid request: something odd" {
+		t.Fatalf("unexpected message: %s", err.Error())
+	}
+
+	var status *StatusError
+	if !errors.As(err, &status) || status.Status != "INVALID_REQUEST" {
+		t.Fatalf("expected StatusError around InvalidRequestError, got %#v", err)
+	}
+
+	err = statusError("directions", "OVER_QUERY_LIMIT", " slow down ")
+	if errors.Is(err, ErrInvalidRequest) || err.Error() != "goplaces: directions status OVER_QUERY_LIMIT: slow down" {
+		t.Fatalf("unexpected status error: %v", err)
+	}
+}
+
+func TestDirectionsStatusErrorZeroResults(t *testing.T) {
+	body := `{"status": "ZERO_RESULTS", "routes": []}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	req := DirectionsRequest{From: "Honolulu", To: "Tokyo", Mode: "drive"}
+	_, err := client.Directions(context.Background(), req)
+	var status *StatusError
+	if !errors.As(err, &status) || status.Status != "ZERO_RESULTS" || status.API != "directions" {
+		t.Fatalf("expected ZERO_RESULTS StatusError, got %#v", err)
+	}
+	if !errors.Is(err, ErrNoRoute) || err.Error() != "goplaces: no route found (directions status ZERO_RESULTS)" {
+		t.Fatalf("expected ErrNoRoute, got %v", err)
+	}
+
+	body = `{"status": "OK", "routes": []}`
+	if _, err := client.Directions(context.Background(), req); !errors.Is(err, ErrNoRoute) {
+		t.Fatalf("expected ErrNoRoute for empty routes, got %v", err)
+	}
+	body = `{"status": "OK", "routes": [`
+	if _, err := client.Directions(context.Background(), req); err == nil || errors.Is(err, ErrNoRoute) {
+		t.Fatalf("malformed responses are not ErrNoRoute, got %v", err)
+	}
+}
+
+func TestDirectionsDurationInTraffic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("traffic_model") == "" {
+			_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{"duration": {"text": "10 mins", "value": 600}}]}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{
+			"duration": {"text": "10 mins", "value": 600},
+			"duration_in_traffic": {"text": "14 mins", "value": 840},
+			"steps": []
+		}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	departure := time.Unix(1700000000, 0)
+	response, err := client.Directions(context.Background(), DirectionsRequest{
+		From: "A", To: "B", Mode: "drive", DepartureTime: &departure, TrafficModel: "best_guess",
+	})
+	if err != nil {
+		t.Fatalf("Directions error: %v", err)
+	}
+	if response.DurationInTrafficSeconds != 840 || response.DurationInTrafficText != "14 mins" {
+		t.Fatalf("unexpected traffic duration: %#v", response)
+	}
+	if response.Legs[0].DurationInTrafficSeconds != 840 {
+		t.Fatalf("expected traffic duration on the leg, got %#v", response.Legs[0])
+	}
+
+	// Without traffic data the fields stay empty and out of JSON.
+	response, err = client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B", Mode: "drive"})
+	if err != nil {
+		t.Fatalf("Directions error: %v", err)
+	}
+	payload, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if response.DurationInTrafficSeconds != 0 || strings.Contains(string(payload), "duration_in_traffic") {
+		t.Fatalf("unexpected traffic fields: %s", payload)
+	}
+}
+
+func TestDirectionsLegs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [
+			{
+				"start_address": "A", "end_address": "B",
+				"start_location": {"lat": 1, "lng": 2}, "end_location": {"lat": 3, "lng": 4},
+				"distance": {"text": "1 km", "value": 1000}, "duration": {"text": "5 mins", "value": 300},
+				"steps": [{"html_instructions": "Head <b>north</b>"}]
+			},
+			{
+				"start_address": "B", "end_address": "C",
+				"distance": {"text": "2 km", "value": 2000}, "duration": {"text": "9 mins", "value": 540},
+				"steps": []
+			}
+		]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	response, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "C", Mode: "walk"})
+	if err != nil {
+		t.Fatalf("Directions error: %v", err)
+	}
+	if len(response.Legs) != 2 {
+		t.Fatalf("expected 2 legs, got %#v", response.Legs)
+	}
+	first := response.Legs[0]
+	if first.StartLocation == nil || first.StartLocation.Lat != 1 || first.EndLocation == nil || first.EndLocation.Lng != 4 {
+		t.Fatalf("unexpected first leg locations: %#v", first)
+	}
+	if len(first.Steps) != 1 || first.Steps[0].Instruction != "Head north" {
+		t.Fatalf("unexpected first leg steps: %#v", first.Steps)
+	}
+	if second := response.Legs[1]; second.EndAddress != "C" || second.DistanceMeters != 2000 || second.StartLocation != nil {
+		t.Fatalf("unexpected second leg: %#v", second)
+	}
+	if response.StartAddress != "A" || response.EndAddress != "C" || len(response.Steps) != 1 {
+		t.Fatalf("top-level fields should span both legs: %#v", response)
+	}
+	if response.DistanceMeters != 3000 || response.DurationSeconds != 840 {
+		t.Fatalf("expected summed totals, got %d m / %d s", response.DistanceMeters, response.DurationSeconds)
+	}
+	if response.DistanceText != "3.0 km" || response.DurationText != "14 mins" {
+		t.Fatalf("unexpected total texts: %q / %q", response.DistanceText, response.DurationText)
+	}
+}
+
+func TestDirectionsLocalizedTextPreserved(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{
+			"distance": {"text": "12,4\u00a0km", "value": 12400},
+			"duration": {"text": "1 Std. 3 Min.", "value": 3780},
+			"duration_in_traffic": {"text": "1 Std. 12 Min.", "value": 4320},
+			"steps": [{"html_instructions": "Rechts abbiegen", "distance": {"text": "0,3 km", "value": 300}, "duration": {"text": "1 Min.", "value": 40}}]
+		}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	departure := time.Unix(1700000000, 0)
+	response, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B", Mode: "drive", Language: "de", DepartureTime: &departure})
+	if err != nil {
+		t.Fatalf("Directions error: %v", err)
+	}
+	leg := response.Legs[0]
+	if leg.DistanceText != "12,4\u00a0km" || leg.DurationText != "1 Std. 3 Min." || leg.DurationInTrafficText != "1 Std. 12 Min." || leg.DurationInTrafficSeconds != 4320 {
+		t.Fatalf("leg text not preserved: %#v", leg)
+	}
+	if response.DurationInTrafficText != "1 Std. 12 Min." || response.DistanceText != leg.DistanceText {
+		t.Fatalf("top-level text not preserved: %#v", response)
+	}
+	if step := response.Steps[0]; step.DistanceText != "0,3 km" || step.DurationText != "1 Min." {
+		t.Fatalf("step text not preserved: %#v", step)
+	}
+}
+
+func TestBuildDirectionsURLMatchesValuesEncode(t *testing.T) {
+	query := map[string]string{
+		"origin":      "Pike Place Market, Seattle",
+		"destination": "place_id:abc",
+		"mode":        "transit",
+		"language":    " ",
+		"key":         "ignored",
+	}
+	got, err := buildDirectionsURL(newQueryEndpoint("https://example.com/json#frag"), query, "k&y", 0)
+	if err != nil {
+		t.Fatalf("buildDirectionsURL error: %v", err)
+	}
+	want := "https://example.com/json?destination=place_id%3Aabc&key=k%26y&mode=transit&origin=Pike+Place+Market%2C+Seattle"
+	if got != want {
+		t.Fatalf("unexpected url:\n got %s\nwant %s", got, want)
+	}
+
+	got, err = buildDirectionsURL(newQueryEndpoint("https://example.com/json?client=x"), map[string]string{"mode": "walking"}, "key", 0)
+	if err != nil {
+		t.Fatalf("buildDirectionsURL error: %v", err)
+	}
+	if got != "https://example.com/json?client=x&key=key&mode=walking" {
+		t.Fatalf("unexpected merged url: %s", got)
+	}
+
+	if _, err := buildDirectionsURL(newQueryEndpoint("://bad"), nil, "key", 0); err == nil || !strings.Contains(err.Error(), "invalid directions url") {
+		t.Fatalf("expected invalid url error, got %v", err)
+	}
+}
+
+func BenchmarkBuildDirectionsURL(b *testing.B) {
+	const base = "https://maps.googleapis.com/maps/api/directions/json"
+	query := map[string]string{
+		"origin":      "place_id:ChIJVTPokywQkFQRmtVEaUZlJRA",
+		"destination": "47.620500,-122.349300",
+		"mode":        "driving",
+		"units":       "metric",
+		"language":    "en",
+	}
+
+	b.Run("parse-per-call", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			parsed, _ := url.Parse(base)
+			values := parsed.Query()
+			for key, value := range query {
+				values.Set(key, value)
+			}
+			values.Set("key", "test-key")
+			parsed.RawQuery = values.Encode()
+			_ = parsed.String()
+		}
+	})
+
+	b.Run("cached-endpoint", func(b *testing.B) {
+		endpoint := newQueryEndpoint(base)
+		b.ReportAllocs()
+		for b.Loop() {
+			_, _ = buildDirectionsURL(endpoint, query, "test-key", DefaultMaxURLLength)
+		}
+	})
+}
+
+func TestDirectionsOriginDriftWarning(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// Swapped lat/lng: Google routes from somewhere far away.
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{"start_location": {"lat": -122.3, "lng": 47.6}, "distance": {"value": 1}, "duration": {"value": 1}}]}]}`))
+	}))
+	defer server.Close()
+
+	req := DirectionsRequest{FromLocation: &LatLng{Lat: 47.6, Lng: -122.3}, To: "B", Mode: "drive"}
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	response, err := client.Directions(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Directions error: %v", err)
+	}
+	if len(response.Warnings) != 1 || !strings.Contains(response.Warnings[0], "km from the requested origin") {
+		t.Fatalf("expected drift warning, got %#v", response.Warnings)
+	}
+
+	strict := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL, WarningsAreErrors: true})
+	if _, err := strict.Directions(context.Background(), req); !errors.Is(err, ErrRouteWarning) {
+		t.Fatalf("expected route warning error, got %v", err)
+	}
+}
+
+func TestDirectionsTransitDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{"steps": [
+			{"html_instructions": "Walk to Alexanderplatz", "travel_mode": "WALKING"},
+			{"html_instructions": "Subway towards Pankow", "travel_mode": "TRANSIT", "transit_details": {
+				"departure_stop": {"name": "Alexanderplatz"},
+				"arrival_stop": {"name": "Eberswalder Str."},
+				"departure_time": {"text": "9:05 AM", "time_zone": "Europe/Berlin", "value": 1700035500},
+				"arrival_time": {"text": "9:12 AM", "time_zone": "Europe/Berlin", "value": 1700035920},
+				"headsign": "Pankow",
+				"num_stops": 5,
+				"line": {"name": "U-Bahn U2", "short_name": "U2", "vehicle": {"type": "SUBWAY"}}
+			}}
+		]}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	response, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B", Mode: "transit"})
+	if err != nil {
+		t.Fatalf("Directions error: %v", err)
+	}
+	if response.Steps[0].TransitDetails != nil {
+		t.Fatalf("walking step should have no transit details")
+	}
+	if response.Fare != nil {
+		t.Fatalf("expected no fare without a fare object, got %#v", response.Fare)
+	}
+	details := response.Steps[1].TransitDetails
+	if details == nil {
+		t.Fatalf("expected transit details")
+	}
+	if details.LineShortName != "U2" || details.NumStops != 5 || details.VehicleType != "SUBWAY" || details.Headsign != "Pankow" {
+		t.Fatalf("unexpected transit details: %#v", details)
+	}
+	if details.DepartureStop != "Alexanderplatz" || details.ArrivalStop != "Eberswalder Str." {
+		t.Fatalf("unexpected stops: %#v", details)
+	}
+	if details.DepartureTime == nil || details.DepartureTime.Unix() != 1700035500 || details.ArrivalTimeText != "9:12 AM" {
+		t.Fatalf("unexpected times: %#v", details)
+	}
+}
+
+func TestDirectionsFare(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{
+			"fare": {"currency": "USD", "text": "$2.75", "value": 2.75},
+			"legs": [{"steps": []}]
+		}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	response, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B", Mode: "transit"})
+	if err != nil {
+		t.Fatalf("Directions error: %v", err)
+	}
+	if response.Fare == nil || *response.Fare != (Fare{Currency: "USD", Text: "$2.75", Value: 2.75}) {
+		t.Fatalf("unexpected fare: %#v", response.Fare)
+	}
+}
+
+func TestDirectionsRaw(t *testing.T) {
+	body := `{"status": "OK", "routes": [{"legs": [{"steps": [{"html_instructions": "Head <b>north</b>"}]}]}]}`
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL, Cache: NewMemoryCache(4)})
+	raw, response, err := client.DirectionsRaw(context.Background(), DirectionsRequest{From: "A", To: "B"})
+	if err != nil {
+		t.Fatalf("DirectionsRaw error: %v", err)
+	}
+	if string(raw) != body || response.Steps[0].Instruction != "Head north" {
+		t.Fatalf("unexpected raw %q / response %#v", raw, response)
+	}
+
+	raw[0] = 'x'
+	again, _, err := client.DirectionsRaw(context.Background(), DirectionsRequest{From: "A", To: "B"})
+	if err != nil || string(again) != body || requests != 1 {
+		t.Fatalf("cached body should be intact: %q (requests=%d, err=%v)", again, requests, err)
+	}
+}
+
+func TestFormatInstruction(t *testing.T) {
+	input := "Head <b>north</b> on <b>Main St</b>&nbsp;<div style=\"font-size:0.9em\">Toll road</div>"
+	cases := map[string]string{
+		"":         "Head north on Main St Toll road",
+		"plain":    "Head north on Main St Toll road",
+		"markdown": "Head **north** on **Main St** Toll road",
+		"html":     input,
+	}
+	for format, want := range cases {
+		if got := formatInstruction(input, format); got != want {
+			t.Fatalf("formatInstruction(%q) = %q, want %q", format, got, want)
+		}
+	}
+	if got := formatInstruction("Head <b>north</b>", "markdown"); got != "Head **north**" {
+		t.Fatalf("unexpected markdown: %q", got)
+	}
+}
+
+func TestDirectionsInstructionFormat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{"steps": [{"html_instructions": "Head <b>north</b>"}]}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL})
+	response, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B", InstructionFormat: "Markdown"})
+	if err != nil {
+		t.Fatalf("Directions error: %v", err)
+	}
+	if response.Steps[0].Instruction != "Head **north**" || response.Legs[0].Steps[0].Instruction != "Head **north**" {
+		t.Fatalf("unexpected instruction: %#v", response.Steps)
+	}
+
+	_, err = client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B", InstructionFormat: "rtf"})
+	var validation ValidationError
+	if !errors.As(err, &validation) || validation.Field != "instruction_format" {
+		t.Fatalf("expected instruction_format validation error, got %v", err)
+	}
+}
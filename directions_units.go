@@ -0,0 +1,96 @@
+package goplaces
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+const (
+	metersPerMile = 1609.344
+	metersPerFoot = 0.3048
+)
+
+// FormatDistance renders meters the way Google's distance_text does: "850 m",
+// "1.2 km", and "123 km" for metric; "500 ft", "0.6 mi", and "123 mi" for
+// imperial. Units other than "imperial" format as metric.
+func FormatDistance(meters int, units string) string {
+	if strings.EqualFold(strings.TrimSpace(units), directionsUnitsImperial) {
+		miles := float64(meters) / metersPerMile
+		switch {
+		case miles < 0.1:
+			return fmt.Sprintf("%.0f ft", float64(meters)/metersPerFoot)
+		case miles < 100:
+			return fmt.Sprintf("%.1f mi", miles)
+		default:
+			return fmt.Sprintf("%.0f mi", miles)
+		}
+	}
+	switch {
+	case meters < 1000:
+		return fmt.Sprintf("%d m", meters)
+	case meters < 100_000:
+		return fmt.Sprintf("%.1f km", float64(meters)/1000)
+	default:
+		return fmt.Sprintf("%.0f km", math.Round(float64(meters)/1000))
+	}
+}
+
+// FormatDuration renders seconds the way Google's duration text does, rounded
+// to the minute: "1 min", "45 mins", "1 hour 5 mins", "2 days 3 hours".
+func FormatDuration(seconds int) string {
+	minutes := (seconds + 30) / 60
+	if seconds > 0 && minutes == 0 {
+		minutes = 1
+	}
+	days, hours := minutes/(24*60), minutes/60%24
+	minutes %= 60
+	switch {
+	case days > 0 && hours > 0:
+		return plural(days, "day") + " " + plural(hours, "hour")
+	case days > 0:
+		return plural(days, "day")
+	case hours > 0 && minutes > 0:
+		return plural(hours, "hour") + " " + plural(minutes, "min")
+	case hours > 0:
+		return plural(hours, "hour")
+	default:
+		return plural(minutes, "min")
+	}
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
+
+// InUnits returns a copy whose distance texts (route, legs, and steps) are
+// re-rendered from the meter values with FormatDistance, so one response can
+// be shown in km and miles without a second request. Unknown units leave the
+// response unchanged. Durations and the original response are untouched.
+func (r DirectionsResponse) InUnits(units string) DirectionsResponse {
+	units = strings.ToLower(strings.TrimSpace(units))
+	if _, ok := directionsUnits[units]; !ok {
+		return r
+	}
+	r.Units = units
+	r.DistanceText = FormatDistance(r.DistanceMeters, units)
+	r.Steps = stepsInUnits(r.Steps, units)
+	r.Legs = slices.Clone(r.Legs)
+	for i := range r.Legs {
+		r.Legs[i].DistanceText = FormatDistance(r.Legs[i].DistanceMeters, units)
+		r.Legs[i].Steps = stepsInUnits(r.Legs[i].Steps, units)
+	}
+	return r
+}
+
+func stepsInUnits(steps []DirectionsStep, units string) []DirectionsStep {
+	steps = slices.Clone(steps)
+	for i := range steps {
+		steps[i].DistanceText = FormatDistance(steps[i].DistanceMeters, units)
+	}
+	return steps
+}
@@ -0,0 +1,70 @@
+package goplaces
+
+import "testing"
+
+func TestFormatDistance(t *testing.T) {
+	cases := []struct {
+		meters int
+		units  string
+		want   string
+	}{
+		{850, "metric", "850 m"},
+		{1234, "metric", "1.2 km"},
+		{123456, "", "123 km"},
+		{100, "imperial", "328 ft"},
+		{1000, "Imperial", "0.6 mi"},
+		{200000, "imperial", "124 mi"},
+	}
+	for _, tc := range cases {
+		if got := FormatDistance(tc.meters, tc.units); got != tc.want {
+			t.Fatalf("FormatDistance(%d, %q) = %q, want %q", tc.meters, tc.units, got, tc.want)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	cases := map[int]string{
+		0:      "0 mins",
+		20:     "1 min",
+		840:    "14 mins",
+		3600:   "1 hour",
+		3900:   "1 hour 5 mins",
+		7170:   "2 hours",
+		90000:  "1 day 1 hour",
+		172800: "2 days",
+	}
+	for seconds, want := range cases {
+		if got := FormatDuration(seconds); got != want {
+			t.Fatalf("FormatDuration(%d) = %q, want %q", seconds, got, want)
+		}
+	}
+}
+
+func TestDirectionsResponseInUnits(t *testing.T) {
+	step := DirectionsStep{DistanceText: "1.6 km", DistanceMeters: 1609}
+	response := DirectionsResponse{
+		Units:          "metric",
+		DistanceText:   "3.2 km",
+		DistanceMeters: 3218,
+		DurationText:   "10 mins",
+		Steps:          []DirectionsStep{step},
+		Legs:           []DirectionsLeg{{DistanceText: "3.2 km", DistanceMeters: 3218, Steps: []DirectionsStep{step, step}}},
+	}
+
+	imperial := response.InUnits("IMPERIAL")
+	if imperial.Units != "imperial" || imperial.DistanceText != "2.0 mi" || imperial.DurationText != "10 mins" {
+		t.Fatalf("unexpected route texts: %#v", imperial)
+	}
+	if imperial.Steps[0].DistanceText != "1.0 mi" || imperial.Legs[0].DistanceText != "2.0 mi" || imperial.Legs[0].Steps[1].DistanceText != "1.0 mi" {
+		t.Fatalf("unexpected leg/step texts: %#v", imperial)
+	}
+	if response.Steps[0].DistanceText != "1.6 km" || response.Legs[0].Steps[0].DistanceText != "1.6 km" {
+		t.Fatalf("InUnits mutated the original: %#v", response)
+	}
+	if back := imperial.InUnits("metric"); back.DistanceText != "3.2 km" || back.Steps[0].DistanceText != "1.6 km" {
+		t.Fatalf("unexpected round trip: %#v", back)
+	}
+	if same := response.InUnits("furlongs"); same.DistanceText != "3.2 km" || same.Units != "metric" {
+		t.Fatalf("unknown units should leave the response unchanged: %#v", same)
+	}
+}
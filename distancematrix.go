@@ -0,0 +1,229 @@
+package goplaces
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultDistanceMatrixBaseURL = "https://maps.googleapis.com/maps/api/distancematrix/json"
+	// Google's per-request limits: 25 origins, 25 destinations, 100 elements.
+	maxDistanceMatrixSide     = 25
+	maxDistanceMatrixElements = 100
+)
+
+// MatrixLocation is one Distance Matrix origin or destination. Set exactly one
+// of Text, PlaceID, or Location, as for DirectionsRequest.From/FromPlaceID/FromLocation.
+type MatrixLocation struct {
+	Text     string  `json:"text,omitempty"`
+	PlaceID  string  `json:"place_id,omitempty"`
+	Location *LatLng `json:"location,omitempty"`
+}
+
+// DistanceMatrixRequest asks for travel distance and time between every
+// origin and every destination, without step-by-step routes.
+type DistanceMatrixRequest struct {
+	Origins      []MatrixLocation `json:"origins"`
+	Destinations []MatrixLocation `json:"destinations"`
+	// Mode, Units, Language, Region, and Avoid behave as in DirectionsRequest
+	// (mode defaults to walk, units to metric).
+	Mode     string   `json:"mode,omitempty"`
+	Units    string   `json:"units,omitempty"`
+	Language string   `json:"language,omitempty"`
+	Region   string   `json:"region,omitempty"`
+	Avoid    []string `json:"avoid,omitempty"`
+	// DepartureTime enables traffic-aware durations for drive and timetable
+	// lookups for transit.
+	DepartureTime *time.Time `json:"departure_time,omitempty"`
+}
+
+// DistanceMatrixResponse is the origin × destination grid.
+type DistanceMatrixResponse struct {
+	// Addresses are Google's formatted addresses, in request order.
+	OriginAddresses      []string `json:"origin_addresses,omitempty"`
+	DestinationAddresses []string `json:"destination_addresses,omitempty"`
+	// Rows[i][j] is the trip from origin i to destination j.
+	Rows [][]DistanceMatrixElement `json:"rows"`
+}
+
+// DistanceMatrixElement is one cell of the grid. Status is OK, NOT_FOUND
+// (an endpoint could not be geocoded), ZERO_RESULTS (no route), or
+// MAX_ROUTE_LENGTH_EXCEEDED; the other fields are set only when it is OK.
+type DistanceMatrixElement struct {
+	Status          string `json:"status"`
+	DistanceText    string `json:"distance_text,omitempty"`
+	DistanceMeters  int    `json:"distance_meters,omitempty"`
+	DurationText    string `json:"duration_text,omitempty"`
+	DurationSeconds int    `json:"duration_seconds,omitempty"`
+	// DurationInTraffic* are set only for drives with a DepartureTime.
+	DurationInTrafficText    string `json:"duration_in_traffic_text,omitempty"`
+	DurationInTrafficSeconds int    `json:"duration_in_traffic_seconds,omitempty"`
+}
+
+// Reachable reports whether the API found a route for this cell, so an
+// unreachable pair is not mistaken for a zero-distance one.
+func (e DistanceMatrixElement) Reachable() bool {
+	return e.Status == "OK"
+}
+
+// DistanceMatrix fetches distances and durations between every origin and
+// destination using the Google Distance Matrix API. Each element is billed.
+func (c *Client) DistanceMatrix(ctx context.Context, req DistanceMatrixRequest) (DistanceMatrixResponse, error) {
+	req = applyDistanceMatrixDefaults(req)
+	if err := validateDistanceMatrixRequest(req); err != nil {
+		return DistanceMatrixResponse{}, err
+	}
+
+	origins, err := resolveMatrixLocations("origins", req.Origins)
+	if err != nil {
+		return DistanceMatrixResponse{}, err
+	}
+	destinations, err := resolveMatrixLocations("destinations", req.Destinations)
+	if err != nil {
+		return DistanceMatrixResponse{}, err
+	}
+
+	query := map[string]string{
+		"origins":      origins,
+		"destinations": destinations,
+		"mode":         req.Mode,
+		"units":        req.Units,
+	}
+	if strings.TrimSpace(req.Language) != "" {
+		query["language"] = req.Language
+	}
+	if strings.TrimSpace(req.Region) != "" {
+		query["region"] = req.Region
+	}
+	if len(req.Avoid) > 0 {
+		query["avoid"] = strings.Join(req.Avoid, "|")
+	}
+	if req.DepartureTime != nil {
+		query["departure_time"] = strconv.FormatInt(req.DepartureTime.Unix(), 10)
+	}
+
+	endpoint, err := buildDirectionsURL(c.distanceMatrixEndpoint, query, c.apiKey, c.maxURLLength)
+	if err != nil {
+		return DistanceMatrixResponse{}, err
+	}
+	payload, err := c.doDirectionsRequest(ctx, endpoint)
+	if err != nil {
+		return DistanceMatrixResponse{}, err
+	}
+
+	var apiResponse distanceMatrixAPIResponse
+	if err := json.Unmarshal(payload, &apiResponse); err != nil {
+		return DistanceMatrixResponse{}, fmt.Errorf("goplaces: decode distance matrix response: %w", err)
+	}
+	if apiResponse.Status != "OK" {
+		return DistanceMatrixResponse{}, statusError("distancematrix", apiResponse.Status, apiResponse.ErrorMessage)
+	}
+	if len(apiResponse.Rows) != len(req.Origins) {
+		return DistanceMatrixResponse{}, fmt.Errorf("goplaces: distance matrix returned %d rows for %d origins", len(apiResponse.Rows), len(req.Origins))
+	}
+
+	response := DistanceMatrixResponse{
+		OriginAddresses:      apiResponse.OriginAddresses,
+		DestinationAddresses: apiResponse.DestinationAddresses,
+		Rows:                 make([][]DistanceMatrixElement, len(apiResponse.Rows)),
+	}
+	for i, row := range apiResponse.Rows {
+		if len(row.Elements) != len(req.Destinations) {
+			return DistanceMatrixResponse{}, fmt.Errorf("goplaces: distance matrix row %d has %d elements for %d destinations", i, len(row.Elements), len(req.Destinations))
+		}
+		cells := make([]DistanceMatrixElement, len(row.Elements))
+		for j, element := range row.Elements {
+			cells[j] = DistanceMatrixElement{
+				Status:                   element.Status,
+				DistanceText:             element.Distance.Text,
+				DistanceMeters:           element.Distance.Value,
+				DurationText:             element.Duration.Text,
+				DurationSeconds:          element.Duration.Value,
+				DurationInTrafficText:    element.DurationInTraffic.Text,
+				DurationInTrafficSeconds: element.DurationInTraffic.Value,
+			}
+		}
+		response.Rows[i] = cells
+	}
+	return response, nil
+}
+
+func applyDistanceMatrixDefaults(req DistanceMatrixRequest) DistanceMatrixRequest {
+	normalized := applyDirectionsDefaults(DirectionsRequest{Mode: req.Mode, Units: req.Units, Avoid: req.Avoid})
+	req.Mode = normalized.Mode
+	req.Units = normalized.Units
+	req.Avoid = normalized.Avoid
+	return req
+}
+
+func validateDistanceMatrixRequest(req DistanceMatrixRequest) error {
+	if normalizeDirectionsMode(req.Mode) == "" {
+		return ValidationError{Field: "mode", Message: "must be walk, drive, bicycle, or transit"}
+	}
+	if _, ok := directionsUnits[req.Units]; !ok {
+		return ValidationError{Field: "units", Message: "must be metric or imperial"}
+	}
+	for _, side := range []struct {
+		field     string
+		locations []MatrixLocation
+	}{{"origins", req.Origins}, {"destinations", req.Destinations}} {
+		if len(side.locations) == 0 {
+			return ValidationError{Field: side.field, Message: "required"}
+		}
+		if len(side.locations) > maxDistanceMatrixSide {
+			return ValidationError{Field: side.field, Message: fmt.Sprintf("at most %d allowed", maxDistanceMatrixSide)}
+		}
+	}
+	if elements := len(req.Origins) * len(req.Destinations); elements > maxDistanceMatrixElements {
+		return ValidationError{
+			Field:   "destinations",
+			Message: fmt.Sprintf("%d origins × %d destinations exceeds %d elements", len(req.Origins), len(req.Destinations), maxDistanceMatrixElements),
+		}
+	}
+	for _, feature := range req.Avoid {
+		if _, ok := directionsAvoidFeatures[feature]; !ok {
+			return ValidationError{Field: "avoid", Message: fmt.Sprintf("unknown feature %q (want tolls, highways, ferries, or indoor)", feature)}
+		}
+	}
+	if req.DepartureTime != nil && req.Mode != directionsModeDrive && req.Mode != directionsModeTransit {
+		return ValidationError{Field: "departure_time", Message: "only applies to drive or transit"}
+	}
+	return nil
+}
+
+// resolveMatrixLocations formats each location like a Directions origin and
+// joins them with "|".
+func resolveMatrixLocations(field string, locations []MatrixLocation) (string, error) {
+	resolved := make([]string, len(locations))
+	for i, location := range locations {
+		value, err := resolveDirectionsLocation(fmt.Sprintf("%s[%d]", field, i), location.PlaceID, location.Location, location.Text)
+		if err != nil {
+			return "", err
+		}
+		resolved[i] = value
+	}
+	return strings.Join(resolved, "|"), nil
+}
+
+type distanceMatrixAPIResponse struct {
+	Status               string              `json:"status"`
+	ErrorMessage         string              `json:"error_message,omitempty"`
+	OriginAddresses      []string            `json:"origin_addresses"`
+	DestinationAddresses []string            `json:"destination_addresses"`
+	Rows                 []distanceMatrixRow `json:"rows"`
+}
+
+type distanceMatrixRow struct {
+	Elements []distanceMatrixElementPayload `json:"elements"`
+}
+
+type distanceMatrixElementPayload struct {
+	Status            string          `json:"status"`
+	Distance          directionsValue `json:"distance"`
+	Duration          directionsValue `json:"duration"`
+	DurationInTraffic directionsValue `json:"duration_in_traffic"`
+}
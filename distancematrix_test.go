@@ -0,0 +1,107 @@
+package goplaces
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDistanceMatrixGrid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if got := query.Get("origins"); got != "Berlin|place_id:hh" {
+			t.Fatalf("unexpected origins: %q", got)
+		}
+		if got := query.Get("destinations"); got != "Munich|48.137000,11.575000" {
+			t.Fatalf("unexpected destinations: %q", got)
+		}
+		if query.Get("mode") != "driving" || query.Get("units") != "metric" {
+			t.Fatalf("unexpected mode/units: %v", query)
+		}
+		_, _ = w.Write([]byte(`{
+			"status": "OK",
+			"origin_addresses": ["Berlin, Germany", "Hamburg, Germany"],
+			"destination_addresses": ["Munich, Germany", "Marienplatz, Munich, Germany"],
+			"rows": [
+				{"elements": [
+					{"status": "OK", "distance": {"text": "585 km", "value": 584731}, "duration": {"text": "5 hours 40 mins", "value": 20400}},
+					{"status": "OK", "distance": {"text": "586 km", "value": 586002}, "duration": {"text": "5 hours 45 mins", "value": 20700}}
+				]},
+				{"elements": [
+					{"status": "OK", "distance": {"text": "776 km", "value": 775912}, "duration": {"text": "7 hours 10 mins", "value": 25800}},
+					{"status": "ZERO_RESULTS"}
+				]}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DistanceMatrixBaseURL: server.URL})
+	response, err := client.DistanceMatrix(context.Background(), DistanceMatrixRequest{
+		Origins:      []MatrixLocation{{Text: "Berlin"}, {PlaceID: "hh"}},
+		Destinations: []MatrixLocation{{Text: "Munich"}, {Location: &LatLng{Lat: 48.137, Lng: 11.575}}},
+		Mode:         "drive",
+	})
+	if err != nil {
+		t.Fatalf("distance matrix: %v", err)
+	}
+	if len(response.Rows) != 2 || len(response.Rows[0]) != 2 || len(response.Rows[1]) != 2 {
+		t.Fatalf("expected a 2x2 grid, got %#v", response.Rows)
+	}
+	if cell := response.Rows[1][0]; cell.Status != "OK" || cell.DistanceMeters != 775912 || cell.DurationSeconds != 25800 {
+		t.Fatalf("unexpected cell [1][0]: %#v", cell)
+	}
+	if cell := response.Rows[1][1]; cell.Status != "ZERO_RESULTS" || cell.DistanceMeters != 0 {
+		t.Fatalf("unexpected cell [1][1]: %#v", cell)
+	}
+	if !response.Rows[1][0].Reachable() || response.Rows[1][1].Reachable() {
+		t.Fatalf("expected only cell [1][0] reachable: %#v", response.Rows[1])
+	}
+	if response.OriginAddresses[1] != "Hamburg, Germany" {
+		t.Fatalf("unexpected origin addresses: %v", response.OriginAddresses)
+	}
+}
+
+func TestDistanceMatrixValidation(t *testing.T) {
+	client := NewClient(Options{APIKey: "test-key"})
+	many := make([]MatrixLocation, 11)
+	for i := range many {
+		many[i] = MatrixLocation{Text: "x"}
+	}
+	cases := []struct {
+		req   DistanceMatrixRequest
+		field string
+	}{
+		{DistanceMatrixRequest{Destinations: many[:1]}, "origins"},
+		{DistanceMatrixRequest{Origins: many[:1], Destinations: make([]MatrixLocation, 26)}, "destinations"},
+		{DistanceMatrixRequest{Origins: many, Destinations: many}, "destinations"},
+		{DistanceMatrixRequest{Origins: []MatrixLocation{{}}, Destinations: many[:1]}, "origins[0]"},
+		{DistanceMatrixRequest{Origins: many[:1], Destinations: many[:1], Avoid: []string{"tunnels"}}, "avoid"},
+	}
+	for _, tc := range cases {
+		_, err := client.DistanceMatrix(context.Background(), tc.req)
+		var validation ValidationError
+		if !errors.As(err, &validation) || validation.Field != tc.field {
+			t.Fatalf("expected %s validation error, got %v", tc.field, err)
+		}
+	}
+}
+
+func TestDistanceMatrixStatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "MAX_ELEMENTS_EXCEEDED", "rows": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DistanceMatrixBaseURL: server.URL})
+	_, err := client.DistanceMatrix(context.Background(), DistanceMatrixRequest{
+		Origins:      []MatrixLocation{{Text: "A"}},
+		Destinations: []MatrixLocation{{Text: "B"}},
+	})
+	var status *StatusError
+	if !errors.As(err, &status) || status.API != "distancematrix" || status.Status != "MAX_ELEMENTS_EXCEEDED" {
+		t.Fatalf("expected StatusError, got %v", err)
+	}
+}
@@ -1,10 +1,47 @@
 package goplaces
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
 
 // ErrMissingAPIKey indicates a missing API key.
 var ErrMissingAPIKey = fmt.Errorf("goplaces: missing api key")
 
+// ErrURLTooLong indicates a GET request URL exceeds the configured length cap.
+var ErrURLTooLong = fmt.Errorf("goplaces: request url too long")
+
+// ErrResponseTooLarge indicates a response body exceeds Options.MaxResponseBytes.
+var ErrResponseTooLarge = fmt.Errorf("goplaces: response too large")
+
+// ErrRouteWarning indicates a route was rejected because it carried warnings.
+var ErrRouteWarning = fmt.Errorf("goplaces: route has warnings")
+
+// ErrNoRoute indicates Google found no route between the locations (status
+// ZERO_RESULTS, or an OK response without routes).
+var ErrNoRoute = fmt.Errorf("goplaces: no route found")
+
+// ErrIteratorDone is returned by Iterator.Next once all pages are consumed.
+var ErrIteratorDone = fmt.Errorf("goplaces: no more results")
+
+// ErrInvalidRequest indicates Google rejected the request parameters (status INVALID_REQUEST).
+var ErrInvalidRequest = fmt.Errorf("goplaces: invalid request")
+
+// ErrAPINotEnabled indicates the API is not enabled for the key's Cloud project.
+var ErrAPINotEnabled = fmt.Errorf("goplaces: api not enabled")
+
+// ErrKeyInvalid indicates Google does not recognize the API key.
+var ErrKeyInvalid = fmt.Errorf("goplaces: api key invalid")
+
+// ErrReferrerRestricted indicates the key's restrictions (HTTP referrer, IP, or
+// API list) reject the request.
+var ErrReferrerRestricted = fmt.Errorf("goplaces: api key restricted")
+
 // ValidationError describes an invalid request payload.
 type ValidationError struct {
 	Field   string
@@ -15,15 +52,218 @@ func (e ValidationError) Error() string {
 	return fmt.Sprintf("goplaces: invalid %s: %s", e.Field, e.Message)
 }
 
-// APIError represents an HTTP error from the Places API.
+// APIError represents an HTTP error from a Google API. Status, Message, and
+// Reason come from Google's JSON error envelope and are empty when the body
+// is not one.
 type APIError struct {
 	StatusCode int
 	Body       string
+	// Status is the canonical code, e.g. PERMISSION_DENIED or INVALID_ARGUMENT.
+	Status  string
+	Message string
+	// Reason is the first ErrorInfo reason in details, e.g. SERVICE_DISABLED.
+	Reason string
+}
+
+// newAPIError builds an APIError from a failed response, parsing Google's
+// {"error": {...}} envelope when present.
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode, Body: redactKey(strings.TrimSpace(string(body)))}
+	var envelope struct {
+		Error struct {
+			Message string `json:"message"`
+			Status  string `json:"status"`
+			Details []struct {
+				Reason string `json:"reason"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return apiErr
+	}
+	apiErr.Status = envelope.Error.Status
+	apiErr.Message = redactKey(strings.TrimSpace(envelope.Error.Message))
+	for _, detail := range envelope.Error.Details {
+		if detail.Reason != "" {
+			apiErr.Reason = detail.Reason
+			break
+		}
+	}
+	return apiErr
 }
 
 func (e *APIError) Error() string {
-	if e.Body == "" {
-		return fmt.Sprintf("goplaces: api error (%d)", e.StatusCode)
+	detail := e.Body
+	code := fmt.Sprintf("%d", e.StatusCode)
+	if e.Message != "" {
+		detail = e.Message
+		if e.Status != "" {
+			code += " " + e.Status
+		}
+	}
+	if detail == "" {
+		return fmt.Sprintf("goplaces: api error (%s)", code)
+	}
+	if hint := deniedHint(e.Unwrap(), "the API"); hint != "" {
+		return fmt.Sprintf("goplaces: api error (%s): %s: %s", code, hint, detail)
+	}
+	return fmt.Sprintf("goplaces: api error (%s): %s", code, detail)
+}
+
+// Unwrap classifies key and project failures, so callers can match
+// ErrAPINotEnabled, ErrKeyInvalid, or ErrReferrerRestricted.
+func (e *APIError) Unwrap() error {
+	if e.StatusCode != http.StatusBadRequest && e.StatusCode != http.StatusForbidden {
+		return nil
+	}
+	return deniedReason(e.Body)
+}
+
+// RequestDeniedError is returned for the legacy REQUEST_DENIED status. Reason is
+// ErrAPINotEnabled, ErrKeyInvalid, ErrReferrerRestricted, or nil if Google's
+// message was not recognized.
+type RequestDeniedError struct {
+	API     string
+	Reason  error
+	Message string
+}
+
+func (e *RequestDeniedError) Error() string {
+	if hint := deniedHint(e.Reason, e.API); hint != "" {
+		return fmt.Sprintf("goplaces: %s request denied: %s (%s)", e.API, hint, e.Message)
+	}
+	return fmt.Sprintf("goplaces: %s request denied: %s", e.API, e.Message)
+}
+
+// Unwrap lets callers match the classified Reason with errors.Is.
+func (e *RequestDeniedError) Unwrap() error {
+	return e.Reason
+}
+
+// deniedReason maps Google's error text to a sentinel. Restrictions are checked
+// first: "This IP, site or mobile application is not authorized to use this
+// API key" would otherwise read as a disabled API.
+func deniedReason(message string) error {
+	lower := strings.ToLower(message)
+	switch {
+	case containsAny(lower, "referer", "referrer", "ip, site or mobile application", "api_key_ip_address_blocked", "api_key_service_blocked"):
+		return ErrReferrerRestricted
+	case containsAny(lower, "api key not valid", "provided api key is invalid", "api_key_invalid", "api key expired"):
+		return ErrKeyInvalid
+	case containsAny(lower, "has not been used in project", "it is disabled", "not authorized to use this api", "service_disabled"):
+		return ErrAPINotEnabled
+	}
+	return nil
+}
+
+func deniedHint(reason error, api string) string {
+	switch reason {
+	case ErrAPINotEnabled:
+		return fmt.Sprintf("enable %s in Google Cloud Console (APIs & Services > Library) for the key's project", api)
+	case ErrKeyInvalid:
+		return "check GOOGLE_PLACES_API_KEY; copy a valid key from Google Cloud Console (APIs & Services > Credentials)"
+	case ErrReferrerRestricted:
+		return fmt.Sprintf("the key's restrictions block this request; server-side use needs no HTTP referrer restriction and %s on the key's API list", api)
+	}
+	return ""
+}
+
+func containsAny(value string, substrings ...string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(value, substring) {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusError is returned for every non-OK status of the legacy Directions and
+// Geocoding APIs, so callers can branch on Status (NOT_FOUND, ZERO_RESULTS,
+// OVER_QUERY_LIMIT, ...) with errors.As. HTTP-level failures stay APIError.
+type StatusError struct {
+	// API is the lowercase endpoint name: directions or geocode.
+	API     string
+	Status  string
+	Message string
+	// Err classifies the status: *InvalidRequestError for INVALID_REQUEST,
+	// *RequestDeniedError for REQUEST_DENIED, ErrNoRoute for a directions
+	// ZERO_RESULTS, or nil.
+	Err error
+}
+
+func (e *StatusError) Error() string {
+	detail := fmt.Sprintf("%s status %s", e.API, e.Status)
+	if e.Message != "" {
+		detail += ": " + e.Message
+	}
+	switch e.Err.(type) {
+	case nil:
+		return "goplaces: " + detail
+	case *InvalidRequestError, *RequestDeniedError:
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s (%s)", e.Err, detail)
+}
+
+// Unwrap exposes Err to errors.Is and errors.As.
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// RouteWarningError carries the warnings of a route rejected by Options.WarningsAreErrors.
+type RouteWarningError struct {
+	Warnings []string
+}
+
+func (e *RouteWarningError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrRouteWarning.Error(), strings.Join(e.Warnings, "; "))
+}
+
+// Unwrap lets callers match with errors.Is(err, ErrRouteWarning).
+func (e *RouteWarningError) Unwrap() error {
+	return ErrRouteWarning
+}
+
+// InvalidRequestError is returned for Google's INVALID_REQUEST status. Field is
+// the request field the message most likely refers to, or empty when unknown.
+type InvalidRequestError struct {
+	Field   string
+	Message string
+}
+
+func (e *InvalidRequestError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%s: %s", ErrInvalidRequest.Error(), e.Message)
+	}
+	return fmt.Sprintf("%s (%s): %s", ErrInvalidRequest.Error(), e.Field, e.Message)
+}
+
+// Unwrap matches ErrInvalidRequest and, when a field is known, a ValidationError
+// so callers can treat it like a local input error.
+func (e *InvalidRequestError) Unwrap() []error {
+	if e.Field == "" {
+		return []error{ErrInvalidRequest}
+	}
+	return []error{ErrInvalidRequest, ValidationError{Field: e.Field, Message: e.Message}}
+}
+
+// redactedKey replaces the API key wherever goplaces shows a request.
+const redactedKey = "REDACTED"
+
+var keyParamPattern = regexp.MustCompile(`\bkey=[^&#\s"'<>]+`)
+
+// redactKey masks key= query parameters in URLs and error text, so API keys
+// do not end up in logs.
+func redactKey(s string) string {
+	return keyParamPattern.ReplaceAllString(s, "key="+redactedKey)
+}
+
+// redactURLError masks the key in a *url.Error's URL in place, keeping the
+// error chain (context.DeadlineExceeded and friends) intact.
+func redactURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = redactKey(urlErr.URL)
 	}
-	return fmt.Sprintf("goplaces: api error (%d): %s", e.StatusCode, e.Body)
+	return err
 }
@@ -1,6 +1,10 @@
 package goplaces
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 )
@@ -21,3 +25,128 @@ func TestErrorMessages(t *testing.T) {
 		t.Fatalf("unexpected api error: %s", apiErr.Error())
 	}
 }
+
+func TestInvalidRequestErrorMessage(t *testing.T) {
+	err := &InvalidRequestError{Field: "to", Message: "bad destination"}
+	if err.Error() != "goplaces: invalid request (to): bad destination" {
+		t.Fatalf("unexpected invalid request error: %s", err.Error())
+	}
+}
+
+func TestRequestDeniedClassification(t *testing.T) {
+	cases := []struct {
+		message string
+		want    error
+	}{
+		{"This API project is not authorized to use this API.", ErrAPINotEnabled},
+		{"Directions API has not been used in project 123 before or it is disabled.", ErrAPINotEnabled},
+		{"The provided API key is invalid.", ErrKeyInvalid},
+		{"API keys with referer restrictions cannot be used with this API.", ErrReferrerRestricted},
+		{"This IP, site or mobile application is not authorized to use this API key.", ErrReferrerRestricted},
+	}
+	for _, tc := range cases {
+		err := statusError("directions", "REQUEST_DENIED", tc.message)
+		if !errors.Is(err, tc.want) {
+			t.Fatalf("%q: expected %v, got %v", tc.message, tc.want, err)
+		}
+		var denied *RequestDeniedError
+		if !errors.As(err, &denied) || denied.API != "Directions API" || denied.Message != tc.message {
+			t.Fatalf("unexpected denied error: %#v", err)
+		}
+	}
+
+	err := statusError("geocode", "REQUEST_DENIED", "This API project is not authorized to use this API.")
+	if !strings.Contains(err.Error(), "enable Geocoding API in Google Cloud Console") {
+		t.Fatalf("expected actionable message, got %s", err.Error())
+	}
+
+	err = statusError("geocode", "REQUEST_DENIED", "something new")
+	if errors.Is(err, ErrAPINotEnabled) || errors.Is(err, ErrKeyInvalid) || errors.Is(err, ErrReferrerRestricted) {
+		t.Fatalf("expected unclassified denial, got %v", err)
+	}
+	if err.Error() != "goplaces: Geocoding API request denied: something new" {
+		t.Fatalf("unexpected message: %s", err.Error())
+	}
+}
+
+func TestAPIErrorDeniedReason(t *testing.T) {
+	notEnabled := &APIError{StatusCode: 403, Body: `{"error": {"status": "PERMISSION_DENIED", "details": [{"reason": "SERVICE_DISABLED"}]}}`}
+	if !errors.Is(notEnabled, ErrAPINotEnabled) || !strings.Contains(notEnabled.Error(), "enable the API") {
+		t.Fatalf("expected not-enabled classification: %v", notEnabled)
+	}
+	invalid := &APIError{StatusCode: 400, Body: `{"error": {"message": "API key not valid. Please pass a valid API key."}}`}
+	if !errors.Is(invalid, ErrKeyInvalid) {
+		t.Fatalf("expected invalid key classification: %v", invalid)
+	}
+	blocked := &APIError{StatusCode: 403, Body: `{"error": {"message": "Requests from referer <empty> are blocked."}}`}
+	if !errors.Is(blocked, ErrReferrerRestricted) {
+		t.Fatalf("expected referrer classification: %v", blocked)
+	}
+	server := &APIError{StatusCode: 500, Body: "API key not valid"}
+	if errors.Is(server, ErrKeyInvalid) {
+		t.Fatalf("expected 5xx to stay unclassified")
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	body := []byte(`{"error": {"code": 403, "message": "Places API (New) has not been used in project 1 before or it is disabled.", "status": "PERMISSION_DENIED",
+		"details": [{"@type": "type.googleapis.com/google.rpc.Help"}, {"@type": "type.googleapis.com/google.rpc.ErrorInfo", "reason": "SERVICE_DISABLED"}]}}`)
+	apiErr := newAPIError(403, body)
+	if apiErr.Status != "PERMISSION_DENIED" || apiErr.Reason != "SERVICE_DISABLED" || !strings.HasPrefix(apiErr.Message, "Places API (New)") {
+		t.Fatalf("unexpected parsed error: %#v", apiErr)
+	}
+	if !errors.Is(apiErr, ErrAPINotEnabled) {
+		t.Fatalf("expected not-enabled classification")
+	}
+	if !strings.HasPrefix(apiErr.Error(), "goplaces: api error (403 PERMISSION_DENIED): enable the API") || strings.Contains(apiErr.Error(), "@type") {
+		t.Fatalf("unexpected message: %s", apiErr.Error())
+	}
+
+	plain := newAPIError(502, []byte("  Bad Gateway\n"))
+	if plain.Body != "Bad Gateway" || plain.Status != "" || plain.Message != "" {
+		t.Fatalf("unexpected plain error: %#v", plain)
+	}
+	if plain.Error() != "goplaces: api error (502): Bad Gateway" {
+		t.Fatalf("unexpected message: %s", plain.Error())
+	}
+}
+
+func TestRedactKey(t *testing.T) {
+	cases := map[string]string{
+		"https://x/json?key=secret&mode=walking": "https://x/json?key=REDACTED&mode=walking",
+		"a=1&key=secret":                         "a=1&key=REDACTED",
+		`Get "https://x?key=secret": EOF`:        `Get "https://x?key=REDACTED": EOF`,
+		"monkey=business&api_key=x":              "monkey=business&api_key=x",
+		"no keys here":                           "no keys here",
+	}
+	for input, want := range cases {
+		if got := redactKey(input); got != want {
+			t.Fatalf("redactKey(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestErrorsRedactAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("bad request for " + r.URL.String()))
+	}))
+	client := NewClient(Options{APIKey: "secret-key", DirectionsBaseURL: server.URL})
+	_, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || strings.Contains(err.Error(), "secret-key") || !strings.Contains(apiErr.Body, "key=REDACTED") {
+		t.Fatalf("expected a redacted APIError, got %v", err)
+	}
+
+	// Transport errors quote the request URL.
+	server.Close()
+	_, err = client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B"})
+	if err == nil || strings.Contains(err.Error(), "secret-key") || !strings.Contains(err.Error(), "key=REDACTED") {
+		t.Fatalf("expected a redacted transport error, got %v", err)
+	}
+
+	err = Options{DirectionsBaseURL: "ftp://x/json?key=secret-key"}.Validate()
+	if err == nil || strings.Contains(err.Error(), "secret-key") {
+		t.Fatalf("expected a redacted validation error, got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package goplaces
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Common Places API (New) field paths for BuildFieldMask.
+const (
+	FieldID                  = "id"
+	FieldName                = "displayName"
+	FieldAddress             = "formattedAddress"
+	FieldLocation            = "location"
+	FieldRating              = "rating"
+	FieldUserRatingCount     = "userRatingCount"
+	FieldPriceLevel          = "priceLevel"
+	FieldPriceRange          = "priceRange"
+	FieldTypes               = "types"
+	FieldPrimaryType         = "primaryType"
+	FieldPrimaryTypeName     = "primaryTypeDisplayName"
+	FieldRegularOpeningHours = "regularOpeningHours"
+	FieldCurrentOpeningHours = "currentOpeningHours"
+	FieldPhone               = "nationalPhoneNumber"
+	FieldWebsite             = "websiteUri"
+	FieldReviews             = "reviews"
+	FieldPhotos              = "photos"
+	FieldEVChargeOptions     = "evChargeOptions"
+	FieldEditorialSummary    = "editorialSummary"
+)
+
+var fieldMaskSegmentPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// BuildFieldMask validates field paths and joins them into an
+// X-Goog-FieldMask value. Duplicates are dropped; "*" must stand alone.
+func BuildFieldMask(fields ...string) (string, error) {
+	if len(fields) == 0 {
+		return "", ValidationError{Field: "field_mask", Message: "at least one field required"}
+	}
+	seen := make(map[string]struct{}, len(fields))
+	paths := make([]string, 0, len(fields))
+	for _, field := range fields {
+		path := strings.TrimSpace(field)
+		if err := validateFieldPath(path); err != nil {
+			return "", err
+		}
+		if path == "*" && len(fields) > 1 {
+			return "", ValidationError{Field: "field_mask", Message: "* cannot be combined with other fields"}
+		}
+		if _, ok := seen[path]; ok {
+			continue
+		}
+		seen[path] = struct{}{}
+		paths = append(paths, path)
+	}
+	return strings.Join(paths, ","), nil
+}
+
+// PlacesFields prefixes fields with "places." for search-style responses.
+func PlacesFields(fields ...string) []string {
+	prefixed := make([]string, len(fields))
+	for i, field := range fields {
+		prefixed[i] = "places." + strings.TrimSpace(field)
+	}
+	return prefixed
+}
+
+func validateFieldPath(path string) error {
+	if path == "*" {
+		return nil
+	}
+	if path == "" {
+		return ValidationError{Field: "field_mask", Message: "empty field path"}
+	}
+	for _, segment := range strings.Split(path, ".") {
+		if !fieldMaskSegmentPattern.MatchString(segment) {
+			return ValidationError{Field: "field_mask", Message: fmt.Sprintf("invalid field path %q", path)}
+		}
+	}
+	return nil
+}
+
+// mustFieldMask builds the package's own masks; a failure is a programming error.
+func mustFieldMask(fields ...string) string {
+	mask, err := BuildFieldMask(fields...)
+	if err != nil {
+		panic(err)
+	}
+	return mask
+}
@@ -0,0 +1,52 @@
+package goplaces
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuildFieldMask(t *testing.T) {
+	mask, err := BuildFieldMask(FieldName, " rating ", FieldName, "places.location.latitude")
+	if err != nil {
+		t.Fatalf("BuildFieldMask error: %v", err)
+	}
+	if mask != "displayName,rating,places.location.latitude" {
+		t.Fatalf("unexpected mask: %s", mask)
+	}
+	if mask, err := BuildFieldMask("*"); err != nil || mask != "*" {
+		t.Fatalf("unexpected wildcard mask: %q %v", mask, err)
+	}
+	if got := PlacesFields(FieldID, FieldRating); got[0] != "places.id" || got[1] != "places.rating" {
+		t.Fatalf("unexpected places fields: %#v", got)
+	}
+	if resolveFieldMask != "places.id,places.displayName,places.formattedAddress,places.location,places.types" {
+		t.Fatalf("unexpected resolve mask: %s", resolveFieldMask)
+	}
+}
+
+func TestBuildFieldMaskRejectsBadPaths(t *testing.T) {
+	cases := [][]string{
+		nil,
+		{""},
+		{"places..id"},
+		{"display name"},
+		{"rating,types"},
+		{"1rating"},
+		{"*", "rating"},
+	}
+	for _, fields := range cases {
+		var validation ValidationError
+		if _, err := BuildFieldMask(fields...); !errors.As(err, &validation) || validation.Field != "field_mask" {
+			t.Fatalf("expected validation error for %#v, got %v", fields, err)
+		}
+	}
+}
+
+func TestMustFieldMaskPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic")
+		}
+	}()
+	_ = mustFieldMask("bad path")
+}
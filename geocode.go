@@ -0,0 +1,354 @@
+package goplaces
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultGeocodeBaseURL = "https://maps.googleapis.com/maps/api/geocode/json"
+	// countryCacheTTL bounds how long geocoded countries are reused; Maps
+	// Platform terms only allow temporary caching of geocoding results.
+	countryCacheTTL = 24 * time.Hour
+	// maxCountryCacheEntries caps the cache for long-running clients that
+	// route from many coordinates.
+	maxCountryCacheEntries = 1024
+)
+
+type countryCacheEntry struct {
+	country string
+	expires time.Time
+}
+
+// imperialCountries drive in miles; Directions "imperial" units match their road signs.
+var imperialCountries = map[string]struct{}{
+	"US": {},
+	"GB": {},
+	"LR": {},
+	"MM": {},
+}
+
+// GeocodeRequest converts an address into coordinates with the Geocoding API.
+type GeocodeRequest struct {
+	Address  string `json:"address,omitempty"`
+	Region   string `json:"region,omitempty"`
+	Language string `json:"language,omitempty"`
+	// Components restricts matches, e.g. {"country": "DE", "postal_code":
+	// "10115"}. Without an Address, Google geocodes the components alone.
+	Components map[string]string `json:"components,omitempty"`
+}
+
+// GeocodeResult is one Geocoding API match.
+type GeocodeResult struct {
+	FormattedAddress string   `json:"formatted_address,omitempty"`
+	Location         LatLng   `json:"location"`
+	PlaceID          string   `json:"place_id,omitempty"`
+	Types            []string `json:"types,omitempty"`
+	// PartialMatch means Google could not match the full address and guessed.
+	PartialMatch bool `json:"partial_match,omitempty"`
+	// LocationType is how precise Location is: ROOFTOP, RANGE_INTERPOLATED,
+	// GEOMETRIC_CENTER, or APPROXIMATE.
+	LocationType string `json:"location_type,omitempty"`
+}
+
+// ReverseGeocodeOptions filters reverse geocoding results.
+type ReverseGeocodeOptions struct {
+	// ResultTypes keeps only results of these address types, e.g.
+	// street_address, locality, or country.
+	ResultTypes []string `json:"result_types,omitempty"`
+	// LocationTypes keeps only results of these precisions (see
+	// GeocodeResult.LocationType); case-insensitive.
+	LocationTypes []string `json:"location_types,omitempty"`
+	Language      string   `json:"language,omitempty"`
+}
+
+var geocodeLocationTypes = map[string]struct{}{
+	"ROOFTOP":            {},
+	"RANGE_INTERPOLATED": {},
+	"GEOMETRIC_CENTER":   {},
+	"APPROXIMATE":        {},
+}
+
+// Geocode looks up an address, best match first. No match is an empty
+// result, not an error. Each call is a billed Geocoding API request.
+func (c *Client) Geocode(ctx context.Context, req GeocodeRequest) ([]GeocodeResult, error) {
+	address := strings.TrimSpace(req.Address)
+	if address == "" && len(req.Components) == 0 {
+		return nil, ValidationError{Field: "address", Message: "required (or components)"}
+	}
+	query := map[string]string{}
+	if address != "" {
+		query["address"] = address
+	}
+	if len(req.Components) > 0 {
+		components, err := formatGeocodeComponents(req.Components)
+		if err != nil {
+			return nil, err
+		}
+		query["components"] = components
+	}
+	if region := strings.TrimSpace(req.Region); region != "" {
+		query["region"] = region
+	}
+	if language := strings.TrimSpace(req.Language); language != "" {
+		query["language"] = language
+	}
+
+	results, err := c.geocode(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return mapGeocodeResults(results), nil
+}
+
+// ReverseGeocode returns the addresses at a coordinate, most specific first,
+// e.g. to label the ends of a route given raw coordinates. No match is an
+// empty result, not an error.
+func (c *Client) ReverseGeocode(ctx context.Context, location LatLng, opts ReverseGeocodeOptions) ([]GeocodeResult, error) {
+	if err := validateDirectionsLocation("location", "", &location, ""); err != nil {
+		return nil, err
+	}
+	query := map[string]string{"latlng": formatLatLng(location)}
+	if len(opts.ResultTypes) > 0 {
+		types := make([]string, 0, len(opts.ResultTypes))
+		for _, kind := range opts.ResultTypes {
+			kind = strings.ToLower(strings.TrimSpace(kind))
+			if kind == "" {
+				return nil, ValidationError{Field: "result_types", Message: "empty type"}
+			}
+			types = append(types, kind)
+		}
+		query["result_type"] = strings.Join(types, "|")
+	}
+	if len(opts.LocationTypes) > 0 {
+		precisions := make([]string, 0, len(opts.LocationTypes))
+		for _, precision := range opts.LocationTypes {
+			precision = strings.ToUpper(strings.TrimSpace(precision))
+			if _, ok := geocodeLocationTypes[precision]; !ok {
+				return nil, ValidationError{
+					Field:   "location_types",
+					Message: fmt.Sprintf("unknown type %q (want ROOFTOP, RANGE_INTERPOLATED, GEOMETRIC_CENTER, or APPROXIMATE)", precision),
+				}
+			}
+			precisions = append(precisions, precision)
+		}
+		query["location_type"] = strings.Join(precisions, "|")
+	}
+	if language := strings.TrimSpace(opts.Language); language != "" {
+		query["language"] = language
+	}
+
+	results, err := c.geocode(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return mapGeocodeResults(results), nil
+}
+
+// formatGeocodeComponents renders the components filter ("country:DE|...")
+// in key order so identical requests share cache entries.
+func formatGeocodeComponents(components map[string]string) (string, error) {
+	keys := make([]string, 0, len(components))
+	for key := range components {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		name, value := strings.TrimSpace(key), strings.TrimSpace(components[key])
+		if name == "" || value == "" {
+			return "", ValidationError{Field: "components", Message: fmt.Sprintf("empty component %q:%q", key, components[key])}
+		}
+		parts = append(parts, name+":"+value)
+	}
+	return strings.Join(parts, "|"), nil
+}
+
+func mapGeocodeResults(results []geocodeResult) []GeocodeResult {
+	mapped := make([]GeocodeResult, 0, len(results))
+	for _, result := range results {
+		mapped = append(mapped, GeocodeResult{
+			FormattedAddress: result.FormattedAddress,
+			Location:         LatLng{Lat: result.Geometry.Location.Lat, Lng: result.Geometry.Location.Lng},
+			PlaceID:          result.PlaceID,
+			Types:            result.Types,
+			PartialMatch:     result.PartialMatch,
+			LocationType:     result.Geometry.LocationType,
+		})
+	}
+	return mapped
+}
+
+// regionForLocation reverse-geocodes a coordinate to a Directions region code.
+func (c *Client) regionForLocation(ctx context.Context, loc LatLng) (string, error) {
+	country, err := c.countryForLocation(ctx, loc)
+	if err != nil || country == "" {
+		return "", err
+	}
+	return directionsRegionCode(country), nil
+}
+
+// unitsForDestination picks imperial or metric units from the destination's country.
+func (c *Client) unitsForDestination(ctx context.Context, req DirectionsRequest) (string, error) {
+	var (
+		country string
+		err     error
+	)
+	switch {
+	case req.ToLocation != nil:
+		country, err = c.countryForLocation(ctx, *req.ToLocation)
+	case req.ToPlaceID != "":
+		country, err = c.countryFor(ctx, "place_id:"+req.ToPlaceID, map[string]string{"place_id": req.ToPlaceID})
+	default:
+		country, err = c.countryFor(ctx, "address:"+strings.ToLower(req.To), map[string]string{"address": req.To})
+	}
+	if err != nil {
+		return "", err
+	}
+	if _, ok := imperialCountries[country]; ok {
+		return directionsUnitsImperial, nil
+	}
+	return directionsUnitsMetric, nil
+}
+
+// countryForLocation caches per ~100m cell since countries rarely change underfoot.
+func (c *Client) countryForLocation(ctx context.Context, loc LatLng) (string, error) {
+	key := fmt.Sprintf("latlng:%.3f,%.3f", loc.Lat, loc.Lng)
+	return c.countryFor(ctx, key, map[string]string{
+		"latlng":      formatLatLng(loc),
+		"result_type": "country",
+	})
+}
+
+// countryFor geocodes query and returns the uppercase ISO country code, or ""
+// when Google finds none. Results (including misses) are cached under key.
+func (c *Client) countryFor(ctx context.Context, key string, query map[string]string) (string, error) {
+	now := c.now()
+	c.countryMu.Lock()
+	entry, ok := c.countryCache[key]
+	c.countryMu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.country, nil
+	}
+
+	results, err := c.geocode(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	country := ""
+	for _, result := range results {
+		if code := countryCode(result); code != "" {
+			country = strings.ToUpper(code)
+			break
+		}
+	}
+
+	c.countryMu.Lock()
+	c.storeCountry(key, countryCacheEntry{country: country, expires: now.Add(countryCacheTTL)}, now)
+	c.countryMu.Unlock()
+	return country, nil
+}
+
+// storeCountry adds entry, first dropping expired entries and then, if the
+// cache is still full, the one closest to expiry (all share one TTL, so that
+// is the oldest). Callers hold countryMu.
+func (c *Client) storeCountry(key string, entry countryCacheEntry, now time.Time) {
+	if _, ok := c.countryCache[key]; !ok && len(c.countryCache) >= maxCountryCacheEntries {
+		oldestKey := ""
+		var oldest time.Time
+		for cached, value := range c.countryCache {
+			if !now.Before(value.expires) {
+				delete(c.countryCache, cached)
+				continue
+			}
+			if oldestKey == "" || value.expires.Before(oldest) {
+				oldestKey, oldest = cached, value.expires
+			}
+		}
+		if len(c.countryCache) >= maxCountryCacheEntries {
+			delete(c.countryCache, oldestKey)
+		}
+	}
+	c.countryCache[key] = entry
+}
+
+func (c *Client) geocode(ctx context.Context, query map[string]string) ([]geocodeResult, error) {
+	endpoint, err := buildDirectionsURL(c.geocodeEndpoint, query, c.apiKey, c.maxURLLength)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := c.doDirectionsRequest(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var response geocodeAPIResponse
+	if err := json.Unmarshal(payload, &response); err != nil {
+		return nil, fmt.Errorf("goplaces: decode geocode response: %w", err)
+	}
+	switch response.Status {
+	case "OK":
+		return response.Results, nil
+	case "ZERO_RESULTS":
+		return nil, nil
+	default:
+		return nil, statusError("geocode", response.Status, response.ErrorMessage)
+	}
+}
+
+func countryCode(result geocodeResult) string {
+	for _, component := range result.AddressComponents {
+		for _, kind := range component.Types {
+			if kind == "country" {
+				return component.ShortName
+			}
+		}
+	}
+	return ""
+}
+
+// directionsRegionCode converts an ISO country code to the ccTLD the Directions API expects.
+func directionsRegionCode(country string) string {
+	code := strings.ToLower(strings.TrimSpace(country))
+	if code == "gb" {
+		// The Directions API uses ccTLDs, and the UK's is .uk.
+		return "uk"
+	}
+	return code
+}
+
+type geocodeAPIResponse struct {
+	Status       string          `json:"status"`
+	ErrorMessage string          `json:"error_message,omitempty"`
+	Results      []geocodeResult `json:"results"`
+}
+
+type geocodeResult struct {
+	FormattedAddress  string                    `json:"formatted_address,omitempty"`
+	PlaceID           string                    `json:"place_id,omitempty"`
+	Types             []string                  `json:"types,omitempty"`
+	Geometry          geocodeGeometry           `json:"geometry"`
+	AddressComponents []geocodeAddressComponent `json:"address_components,omitempty"`
+	PartialMatch      bool                      `json:"partial_match,omitempty"`
+}
+
+type geocodeGeometry struct {
+	Location     latLngPayload `json:"location"`
+	LocationType string        `json:"location_type,omitempty"`
+}
+
+type geocodeAddressComponent struct {
+	LongName  string   `json:"long_name,omitempty"`
+	ShortName string   `json:"short_name,omitempty"`
+	Types     []string `json:"types,omitempty"`
+}
+
+type latLngPayload struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
@@ -0,0 +1,312 @@
+package goplaces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestDirectionsAutoRegion(t *testing.T) {
+	geocodeCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/geocode":
+			geocodeCalls++
+			if r.URL.Query().Get("latlng") != "51.500000,-0.120000" {
+				t.Fatalf("unexpected latlng: %s", r.URL.Query().Get("latlng"))
+			}
+			_, _ = w.Write([]byte(`{
+				"status": "OK",
+				"results": [{
+					"address_components": [{"long_name": "United Kingdom", "short_name": "GB", "types": ["country", "political"]}]
+				}]
+			}`))
+		case "/directions":
+			if r.URL.Query().Get("region") != "uk" {
+				t.Fatalf("unexpected region: %s", r.URL.Query().Get("region"))
+			}
+			_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{"steps": []}]}]}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{
+		APIKey:            "test-key",
+		DirectionsBaseURL: server.URL + "/directions",
+		GeocodeBaseURL:    server.URL + "/geocode",
+		AutoRegion:        true,
+	})
+	request := DirectionsRequest{FromLocation: &LatLng{Lat: 51.5, Lng: -0.12}, To: "B"}
+	for i := 0; i < 2; i++ {
+		if _, err := client.Directions(context.Background(), request); err != nil {
+			t.Fatalf("Directions error: %v", err)
+		}
+	}
+	if geocodeCalls != 1 {
+		t.Fatalf("expected cached region lookup, got %d geocode calls", geocodeCalls)
+	}
+}
+
+func TestDirectionsAutoRegionExplicitRegion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/directions" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("region") != "de" {
+			t.Fatalf("unexpected region: %s", r.URL.Query().Get("region"))
+		}
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{"steps": []}]}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{
+		APIKey:            "test-key",
+		DirectionsBaseURL: server.URL + "/directions",
+		GeocodeBaseURL:    server.URL + "/geocode",
+		AutoRegion:        true,
+	})
+	_, err := client.Directions(context.Background(), DirectionsRequest{
+		FromLocation: &LatLng{Lat: 51.5, Lng: -0.12},
+		To:           "B",
+		Region:       "de",
+	})
+	if err != nil {
+		t.Fatalf("Directions error: %v", err)
+	}
+}
+
+func TestRegionForLocationZeroResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "ZERO_RESULTS", "results": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", GeocodeBaseURL: server.URL})
+	region, err := client.regionForLocation(context.Background(), LatLng{Lat: 0, Lng: 0})
+	if err != nil {
+		t.Fatalf("regionForLocation error: %v", err)
+	}
+	if region != "" {
+		t.Fatalf("expected empty region, got %q", region)
+	}
+}
+
+func TestRegionForLocationStatusError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "REQUEST_DENIED", "error_message": "nope"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", GeocodeBaseURL: server.URL})
+	if _, err := client.regionForLocation(context.Background(), LatLng{Lat: 1, Lng: 1}); err == nil {
+		t.Fatalf("expected geocode status error")
+	}
+}
+
+func TestDirectionsAutoUnits(t *testing.T) {
+	countries := map[string]string{
+		"address=Portland%2C+OR":       "US",
+		"place_id=berlin":              "DE",
+		"latlng=51.500000%2C-0.120000": "GB",
+		"address=Nowhere":              "",
+	}
+	var lastUnits string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/geocode":
+			query := r.URL.Query()
+			var key string
+			switch {
+			case query.Get("address") != "":
+				key = "address=" + url.QueryEscape(query.Get("address"))
+			case query.Get("place_id") != "":
+				key = "place_id=" + query.Get("place_id")
+			default:
+				key = "latlng=" + url.QueryEscape(query.Get("latlng"))
+			}
+			country, ok := countries[key]
+			if !ok {
+				t.Fatalf("unexpected geocode query: %s", r.URL.RawQuery)
+			}
+			if country == "" {
+				_, _ = w.Write([]byte(`{"status": "ZERO_RESULTS", "results": []}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status": "OK", "results": [{"address_components": [{"short_name": "` + country + `", "types": ["country"]}]}]}`))
+		case "/directions":
+			lastUnits = r.URL.Query().Get("units")
+			_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{"steps": []}]}]}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{
+		APIKey:            "test-key",
+		DirectionsBaseURL: server.URL + "/directions",
+		GeocodeBaseURL:    server.URL + "/geocode",
+		AutoUnits:         true,
+	})
+	cases := []struct {
+		request DirectionsRequest
+		want    string
+	}{
+		{DirectionsRequest{From: "A", To: "Portland, OR"}, directionsUnitsImperial},
+		{DirectionsRequest{From: "A", ToPlaceID: "berlin"}, directionsUnitsMetric},
+		{DirectionsRequest{From: "A", ToLocation: &LatLng{Lat: 51.5, Lng: -0.12}}, directionsUnitsImperial},
+		{DirectionsRequest{From: "A", To: "Nowhere"}, directionsUnitsMetric},
+		{DirectionsRequest{From: "A", To: "Portland, OR", Units: "metric"}, directionsUnitsMetric},
+	}
+	for _, tc := range cases {
+		response, err := client.Directions(context.Background(), tc.request)
+		if err != nil {
+			t.Fatalf("Directions error: %v", err)
+		}
+		if lastUnits != tc.want || response.Units != tc.want {
+			t.Fatalf("expected %s units for %#v, got query=%s response=%s", tc.want, tc.request, lastUnits, response.Units)
+		}
+	}
+}
+
+func TestCountryCacheExpiresWithClock(t *testing.T) {
+	geocodeCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		geocodeCalls++
+		_, _ = w.Write([]byte(`{"status": "OK", "results": [{"address_components": [{"short_name": "DE", "types": ["country"]}]}]}`))
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := NewClient(Options{
+		APIKey:         "test-key",
+		GeocodeBaseURL: server.URL,
+		Clock:          func() time.Time { return now },
+	})
+	location := LatLng{Lat: 52.52, Lng: 13.405}
+	for _, advance := range []time.Duration{0, time.Hour, countryCacheTTL} {
+		now = now.Add(advance)
+		region, err := client.regionForLocation(context.Background(), location)
+		if err != nil || region != "de" {
+			t.Fatalf("unexpected region: %q %v", region, err)
+		}
+	}
+	if geocodeCalls != 2 {
+		t.Fatalf("expected refetch after TTL, got %d geocode calls", geocodeCalls)
+	}
+}
+
+func TestCountryCacheBounded(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := NewClient(Options{APIKey: "test-key"})
+	for i := range maxCountryCacheEntries {
+		client.storeCountry(fmt.Sprint(i), countryCacheEntry{country: "DE", expires: now.Add(time.Duration(i) * time.Second)}, now)
+	}
+	client.storeCountry("new", countryCacheEntry{country: "FR", expires: now.Add(countryCacheTTL)}, now)
+	if _, ok := client.countryCache["0"]; ok || len(client.countryCache) != maxCountryCacheEntries {
+		t.Fatalf("expected the oldest entry evicted, got %d entries", len(client.countryCache))
+	}
+
+	later := now.Add(time.Minute)
+	client.storeCountry("newer", countryCacheEntry{country: "FR", expires: later.Add(countryCacheTTL)}, later)
+	if len(client.countryCache) != maxCountryCacheEntries-60+1 {
+		t.Fatalf("expected expired entries dropped, got %d entries", len(client.countryCache))
+	}
+}
+
+func TestGeocode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("address") != "Brandenburger Tor" || query.Get("components") != "country:DE|locality:Berlin" {
+			t.Fatalf("unexpected query: %v", query)
+		}
+		if query.Get("region") != "de" || query.Get("language") != "en" {
+			t.Fatalf("unexpected region/language: %v", query)
+		}
+		_, _ = w.Write([]byte(`{
+			"status": "OK",
+			"results": [{
+				"formatted_address": "Pariser Platz, 10117 Berlin, Germany",
+				"place_id": "ChIJiQnyVcZRqEcRY0xnhE77uyY",
+				"types": ["establishment", "point_of_interest"],
+				"geometry": {"location": {"lat": 52.5162746, "lng": 13.3777041}}
+			}]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", GeocodeBaseURL: server.URL})
+	results, err := client.Geocode(context.Background(), GeocodeRequest{
+		Address:    " Brandenburger Tor ",
+		Region:     "de",
+		Language:   "en",
+		Components: map[string]string{"locality": "Berlin", "country": "DE"},
+	})
+	if err != nil {
+		t.Fatalf("geocode: %v", err)
+	}
+	if len(results) != 1 || results[0].Location != (LatLng{Lat: 52.5162746, Lng: 13.3777041}) {
+		t.Fatalf("unexpected results: %#v", results)
+	}
+	if results[0].PlaceID != "ChIJiQnyVcZRqEcRY0xnhE77uyY" || results[0].FormattedAddress != "Pariser Platz, 10117 Berlin, Germany" {
+		t.Fatalf("unexpected result: %#v", results[0])
+	}
+
+	var validation ValidationError
+	if _, err := client.Geocode(context.Background(), GeocodeRequest{}); !errors.As(err, &validation) || validation.Field != "address" {
+		t.Fatalf("expected address validation error, got %v", err)
+	}
+	if _, err := client.Geocode(context.Background(), GeocodeRequest{Components: map[string]string{"country": " "}}); !errors.As(err, &validation) || validation.Field != "components" {
+		t.Fatalf("expected components validation error, got %v", err)
+	}
+}
+
+func TestReverseGeocode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("latlng") != "40.714224,-73.961452" {
+			t.Fatalf("unexpected latlng: %s", query.Get("latlng"))
+		}
+		if query.Get("result_type") != "street_address" || query.Get("location_type") != "ROOFTOP|RANGE_INTERPOLATED" {
+			t.Fatalf("unexpected filters: %v", query)
+		}
+		_, _ = w.Write([]byte(`{
+			"status": "OK",
+			"results": [{
+				"formatted_address": "277 Bedford Ave, Brooklyn, NY 11211, USA",
+				"place_id": "ChIJd8BlQ2BZwokRAFUEcm_qrcA",
+				"types": ["street_address"],
+				"geometry": {"location": {"lat": 40.714232, "lng": -73.9612889}, "location_type": "ROOFTOP"}
+			}]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", GeocodeBaseURL: server.URL})
+	results, err := client.ReverseGeocode(context.Background(), LatLng{Lat: 40.714224, Lng: -73.961452}, ReverseGeocodeOptions{
+		ResultTypes:   []string{"street_address"},
+		LocationTypes: []string{"rooftop", "RANGE_INTERPOLATED"},
+	})
+	if err != nil {
+		t.Fatalf("reverse geocode: %v", err)
+	}
+	if len(results) != 1 || results[0].FormattedAddress != "277 Bedford Ave, Brooklyn, NY 11211, USA" || results[0].LocationType != "ROOFTOP" {
+		t.Fatalf("unexpected results: %#v", results)
+	}
+
+	var validation ValidationError
+	if _, err := client.ReverseGeocode(context.Background(), LatLng{Lat: 91}, ReverseGeocodeOptions{}); !errors.As(err, &validation) || validation.Field != "location.lat" {
+		t.Fatalf("expected lat validation error, got %v", err)
+	}
+	_, err = client.ReverseGeocode(context.Background(), LatLng{}, ReverseGeocodeOptions{LocationTypes: []string{"exact"}})
+	if !errors.As(err, &validation) || validation.Field != "location_types" {
+		t.Fatalf("expected location_types validation error, got %v", err)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -126,6 +128,163 @@ func TestRunSearchWithFilters(t *testing.T) {
 	}
 }
 
+func TestRunNearbyEVAvailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.Header.Get("X-Goog-FieldMask"), ",places.evChargeOptions") {
+			t.Fatalf("expected ev field mask, got %s", r.Header.Get("X-Goog-FieldMask"))
+		}
+		_, _ = w.Write([]byte(`{"places": [
+			{"id": "free", "displayName": {"text": "Free"}, "evChargeOptions": {"connectorCount": 4, "connectorAggregation": [{"type": "EV_CONNECTOR_TYPE_CCS_COMBO_2", "count": 4, "availableCount": 2}]}},
+			{"id": "busy", "displayName": {"text": "Busy"}, "evChargeOptions": {"connectorCount": 2, "connectorAggregation": [{"count": 2, "availableCount": 0}]}},
+			{"id": "unknown", "displayName": {"text": "Unknown"}, "evChargeOptions": {"connectorCount": 2, "connectorAggregation": [{"count": 2}]}},
+			{"id": "none", "displayName": {"text": "None"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"nearby",
+		"--lat", "1",
+		"--lng", "2",
+		"--radius-m", "500",
+		"--ev-available",
+		"--no-color",
+		"--api-key", "test-key",
+		"--base-url", server.URL,
+	}, &stdout, &stderr)
+
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	output := stdout.String()
+	if !strings.Contains(output, "Nearby (1)") || !strings.Contains(output, "EV: 2/4 available") {
+		t.Fatalf("unexpected output: %s", output)
+	}
+}
+
+func TestRunNearbyHeadAfterFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["maxResultCount"] != float64(10) {
+			t.Fatalf("--head must not change the fetch size, got %v", body["maxResultCount"])
+		}
+		_, _ = w.Write([]byte(`{"places": [
+			{"id": "busy", "evChargeOptions": {"connectorAggregation": [{"count": 2, "availableCount": 0}]}},
+			{"id": "a", "evChargeOptions": {"connectorAggregation": [{"count": 2, "availableCount": 1}]}},
+			{"id": "b", "evChargeOptions": {"connectorAggregation": [{"count": 2, "availableCount": 1}]}}
+		]}`))
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	exitCode := Run([]string{
+		"nearby", "--lat", "1", "--lng", "2", "--radius-m", "500", "--ev-available", "--head", "1", "--json",
+		"--api-key", "test-key", "--base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	var places []goplaces.PlaceSummary
+	if err := json.Unmarshal(stdout.Bytes(), &places); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(places) != 1 || places[0].PlaceID != "a" {
+		t.Fatalf("expected the first available place only, got %#v", places)
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	if exitCode := Run([]string{"search", "coffee", "--head=-1", "--api-key", "test-key"}, &stdout, &stderr); exitCode != 2 {
+		t.Fatalf("expected exit code 2 for negative --head, got %d", exitCode)
+	}
+}
+
+func TestRunSearchCountOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch {
+		case body["textQuery"] == "nothing":
+			_, _ = w.Write([]byte(`{}`))
+		case body["pageToken"] == "page-2":
+			_, _ = w.Write([]byte(`{"places": [{"id": "c"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"places": [{"id": "a"}, {"id": "b"}], "nextPageToken": "page-2"}`))
+		}
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	exitCode := Run([]string{
+		"search", "coffee", "--count-only", "--json", "--api-key", "test-key", "--base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if stdout.String() != "3\n" {
+		t.Fatalf("expected a count across both pages, got %q", stdout.String())
+	}
+
+	stdout.Reset()
+	if exitCode := Run([]string{
+		"search", "nothing", "--count-only", "--api-key", "test-key", "--base-url", server.URL,
+	}, &stdout, &stderr); exitCode != 0 || stdout.String() != "0\n" {
+		t.Fatalf("expected 0 with exit 0, got %q (exit %d)", stdout.String(), exitCode)
+	}
+
+	stdout.Reset()
+	if exitCode := Run([]string{
+		"search", "nothing", "--count-only", "--fail-on-empty", "--api-key", "test-key", "--base-url", server.URL,
+	}, &stdout, &stderr); exitCode != 1 || stdout.String() != "0\n" {
+		t.Fatalf("expected 0 with exit 1, got %q (exit %d)", stdout.String(), exitCode)
+	}
+}
+
+func TestRunSearchNDJSON(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["pageToken"] == "page-2" {
+			_, _ = w.Write([]byte(`{"places": [{"id": "c"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"places": [{"id": "a"}, {"id": "b"}], "nextPageToken": "page-2"}`))
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	exitCode := Run([]string{
+		"search", "coffee", "--format", "ndjson", "--json-style", "camel",
+		"--api-key", "test-key", "--base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 || requests != 2 {
+		t.Fatalf("expected 3 lines from 2 pages, got %d lines from %d requests: %q", len(lines), requests, stdout.String())
+	}
+	for i, want := range []string{"a", "b", "c"} {
+		var place map[string]any
+		if err := json.Unmarshal([]byte(lines[i]), &place); err != nil || place["placeId"] != want {
+			t.Fatalf("line %d: expected object for %q, got %q (%v)", i, want, lines[i], err)
+		}
+	}
+
+	requests = 0
+	stdout.Reset()
+	Run([]string{"search", "coffee", "--format", "ndjson", "--head", "2", "--api-key", "test-key", "--base-url", server.URL}, &stdout, &stderr)
+	if requests != 1 || strings.Count(stdout.String(), "\n") != 2 {
+		t.Fatalf("--head should stop before the next page, got %d requests: %q", requests, stdout.String())
+	}
+}
+
 func TestRunAutocompleteJSON(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/places:autocomplete" {
@@ -314,6 +473,121 @@ func TestRunRouteHuman(t *testing.T) {
 	}
 }
 
+func TestRunRouteAlternatives(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case routesComputePath:
+			if !strings.Contains(r.Header.Get("X-Goog-FieldMask"), "routes.routeLabels") {
+				t.Fatalf("expected route labels in field mask: %s", r.Header.Get("X-Goog-FieldMask"))
+			}
+			_, _ = w.Write([]byte(`{"routes":[
+				{"routeLabels":["DEFAULT_ROUTE"],"distanceMeters":12000,"duration":"900s","polyline":{"encodedPolyline":"_p~iF~ps|U"}},
+				{"routeLabels":["DEFAULT_ROUTE_ALTERNATE"],"distanceMeters":13100,"duration":"960s","polyline":{"encodedPolyline":"_ulLnnqC"}}
+			]}`))
+		case placesSearchPath:
+			_, _ = w.Write([]byte(`{"places":[]}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"route",
+		"coffee",
+		"--from", "A",
+		"--to", "B",
+		"--alternatives",
+		"--max-waypoints", "1",
+		"--api-key", "test-key",
+		"--base-url", server.URL,
+		"--routes-base-url", server.URL,
+		"--json",
+	}, &stdout, &stderr)
+
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (%s)", exitCode, stderr.String())
+	}
+	var response goplaces.RouteResponse
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		t.Fatalf("decode stdout: %v", err)
+	}
+	if len(response.Routes) != 2 || !response.Routes[1].HasLabel("DEFAULT_ROUTE_ALTERNATE") {
+		t.Fatalf("expected both routes in output, got %#v", response.Routes)
+	}
+}
+
+func TestRunRoutePolylineAndGeoJSON(t *testing.T) {
+	searches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case routesComputePath:
+			_, _ = w.Write([]byte("{\"routes\":[{\"polyline\":{\"encodedPolyline\":\"_p~iF~ps|U_ulLnnqC_mqNvxq`@\"}}]}"))
+		case placesSearchPath:
+			searches++
+			_, _ = w.Write([]byte(`{"places":[{"id":"abc","displayName":{"text":"Cafe"},"location":{"latitude":38.5,"longitude":-120.2}}]}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	run := func(extra ...string) (int, string) {
+		var stdout bytes.Buffer
+		var stderr bytes.Buffer
+		args := append([]string{
+			"route",
+			"coffee",
+			"--from", "A",
+			"--to", "B",
+			"--max-waypoints", "1",
+			"--api-key", "test-key",
+			"--base-url", server.URL,
+			"--routes-base-url", server.URL,
+		}, extra...)
+		return Run(args, &stdout, &stderr), stdout.String()
+	}
+
+	if code, out := run("--polyline"); code != 0 || out != "_p~iF~ps|U_ulLnnqC_mqNvxq`@\n" {
+		t.Fatalf("unexpected --polyline output (exit %d): %q", code, out)
+	}
+	if searches != 0 {
+		t.Fatalf("--polyline should not search for places, got %d searches", searches)
+	}
+
+	code, out := run("--format", "geojson")
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d", code)
+	}
+	var collection struct {
+		Features []struct {
+			Geometry struct {
+				Type        string          `json:"type"`
+				Coordinates json.RawMessage `json:"coordinates"`
+			} `json:"geometry"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal([]byte(out), &collection); err != nil {
+		t.Fatalf("decode geojson: %v", err)
+	}
+	if len(collection.Features) != 2 || collection.Features[0].Geometry.Type != "LineString" ||
+		collection.Features[1].Geometry.Type != "Point" {
+		t.Fatalf("expected a route LineString and a place Point: %s", out)
+	}
+	var line [][2]float64
+	if err := json.Unmarshal(collection.Features[0].Geometry.Coordinates, &line); err != nil ||
+		len(line) != 3 || line[0] != [2]float64{-120.2, 38.5} {
+		t.Fatalf("unexpected line coordinates: %v (%v)", line, err)
+	}
+
+	if code, _ := run("--polyline", "--format", "geojson"); code != 2 {
+		t.Fatalf("expected validation error exit code 2, got %d", code)
+	}
+}
+
 func TestRunRouteValidationError(t *testing.T) {
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
@@ -727,6 +1001,37 @@ func TestWriteJSON(t *testing.T) {
 	}
 }
 
+func TestWriteJSONCompact(t *testing.T) {
+	var out bytes.Buffer
+	app := &App{out: &out, compact: true}
+	if err := app.writeJSON(map[string]string{"ok": "true"}); err != nil {
+		t.Fatalf("writeJSON error: %v", err)
+	}
+	if out.String() != "{\"ok\":\"true\"}\n" {
+		t.Fatalf("unexpected compact output: %q", out.String())
+	}
+	if err := writeCompactJSON(&bytes.Buffer{}, map[string]any{"bad": func() {}}); err == nil {
+		t.Fatalf("expected json error")
+	}
+}
+
+func TestRunJSONCompactImpliesJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": [{"id": "place-1", "displayName": {"text": "Cafe"}}]}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{"search", "coffee", "--api-key", "test-key", "--base-url", server.URL, "--json-compact"}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if strings.Count(stdout.String(), "\n") != 1 || !strings.HasPrefix(stdout.String(), "[{\"place_id\":\"place-1\"") {
+		t.Fatalf("unexpected compact output: %q", stdout.String())
+	}
+}
+
 func TestHandleError(t *testing.T) {
 	if code := handleError(&bytes.Buffer{}, nil); code != 0 {
 		t.Fatalf("expected 0")
@@ -741,3 +1046,63 @@ func TestHandleError(t *testing.T) {
 		t.Fatalf("expected generic exit 1")
 	}
 }
+
+func TestRunInvalidBaseURL(t *testing.T) {
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	exitCode := Run([]string{"search", "coffee", "--api-key", "test-key", "--directions-base-url", "maps.example.com/json"}, &stdout, &stderr)
+	if exitCode != 2 {
+		t.Fatalf("expected exit code 2, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), "directions_base_url") {
+		t.Fatalf("unexpected stderr: %s", stderr.String())
+	}
+}
+
+func TestRunSearchGeoJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": [
+			{"id": "a", "displayName": {"text": "Cafe"}, "formattedAddress": "1 Main St", "rating": 4.5, "location": {"latitude": 47.6, "longitude": -122.3}},
+			{"id": "b", "displayName": {"text": "Nowhere"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	exitCode := Run([]string{"search", "coffee", "--format", "geojson", "--json-compact", "--api-key", "test-key", "--base-url", server.URL}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	want := `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[-122.3,47.6]},"properties":{"name":"Cafe","rating":4.5,"address":"1 Main St","place_id":"a"}}]}` + "\n"
+	if stdout.String() != want {
+		t.Fatalf("unexpected geojson: %s", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "1 place(s) without a location omitted") {
+		t.Fatalf("expected skipped-place warning, got %q", stderr.String())
+	}
+}
+
+func TestRunPhotoOutput(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/media") {
+			http.Redirect(w, r, "/image.jpg", http.StatusFound)
+			return
+		}
+		_, _ = w.Write([]byte("jpeg"))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "photo.jpg")
+	var stdout, stderr bytes.Buffer
+	exitCode := Run([]string{"photo", "places/p/photos/x", "-o", path, "--api-key", "test-key", "--base-url", server.URL}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "jpeg" {
+		t.Fatalf("unexpected file contents %q (%v)", data, err)
+	}
+	if stdout.String() != "Saved 4 bytes to "+path+"\n" {
+		t.Fatalf("unexpected stdout: %q", stdout.String())
+	}
+}
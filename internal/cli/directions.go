@@ -2,27 +2,103 @@ package cli
 
 import (
 	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/steipete/goplaces"
 )
 
 // DirectionsCmd fetches directions between two points.
 type DirectionsCmd struct {
-	From        string   `help:"Origin address or place name."`
-	To          string   `help:"Destination address or place name."`
-	FromPlaceID string   `help:"Origin place ID." name:"from-place-id"`
-	ToPlaceID   string   `help:"Destination place ID." name:"to-place-id"`
-	FromLat     *float64 `help:"Origin latitude." name:"from-lat"`
-	FromLng     *float64 `help:"Origin longitude." name:"from-lng"`
-	ToLat       *float64 `help:"Destination latitude." name:"to-lat"`
-	ToLng       *float64 `help:"Destination longitude." name:"to-lng"`
-	Mode        string   `help:"Travel mode: walk, drive, bicycle, transit." default:"walk"`
-	Compare     string   `help:"Compare with another mode: walk, drive, bicycle, transit."`
-	Steps       bool     `help:"Include step-by-step instructions."`
-	Units       string   `help:"Units: metric or imperial." default:"metric"`
-	Language    string   `help:"BCP-47 language code (e.g. en, en-US)."`
-	Region      string   `help:"CLDR region code (e.g. US, DE)."`
+	From         string   `help:"Origin address or place name."`
+	To           string   `help:"Destination address or place name."`
+	FromPlaceID  string   `help:"Origin place ID." name:"from-place-id"`
+	ToPlaceID    string   `help:"Destination place ID." name:"to-place-id"`
+	FromLat      *float64 `help:"Origin latitude." name:"from-lat"`
+	FromLng      *float64 `help:"Origin longitude." name:"from-lng"`
+	ToLat        *float64 `help:"Destination latitude." name:"to-lat"`
+	ToLng        *float64 `help:"Destination longitude." name:"to-lng"`
+	Mode         string   `help:"Travel mode: walk, drive, bicycle, transit." default:"walk"`
+	Compare      string   `help:"Compare with another mode: walk, drive, bicycle, transit."`
+	Steps        bool     `help:"Include step-by-step instructions."`
+	Units        string   `help:"Units: metric or imperial (default metric)."`
+	Language     string   `help:"BCP-47 language code (e.g. en, en-US); a comma-separated list falls back in order when steps lack instructions."`
+	Region       string   `help:"CLDR region code (e.g. US, DE)."`
+	Now          bool     `help:"Depart now (transit and drive only)."`
+	TrafficModel string   `help:"Traffic model with --now and drive: best_guess, pessimistic, optimistic." name:"traffic-model"`
+	WalkWarnKm   float64  `help:"Warn when a walking route exceeds this distance in km (0 disables)." name:"walk-warn-km" default:"10"`
+	Quiet        bool     `help:"Suppress advisories on stderr."`
+	Diff         bool     `help:"With --compare, print the distance/duration delta and differing steps."`
+	ReturnMode   string   `help:"Add a return leg (To back to From) by this mode: walk, drive, bicycle, transit." name:"return-mode"`
+	Strict       bool     `help:"Fail when Google only partially matched --from or --to."`
+	Via          []string `help:"Intermediate stop (address, place_id:ID, or lat,lng). Repeatable."`
+	Optimize     bool     `help:"Let Google reorder --via stops for the shortest route."`
+	Avoid        []string `help:"Route around tolls, highways, ferries, or indoor. Repeatable."`
+	// ArriveBy is RFC 3339, e.g. 2026-03-01T09:00:00+01:00.
+	ArriveBy      *time.Time `help:"Arrive by this time (RFC 3339; transit only)." name:"arrive-by"`
+	NoBorderCross bool       `help:"Check (best effort) that the route stays in the origin's country; fails with --strict." name:"no-border-cross"`
+	StaticMap     bool       `help:"Print a Static Maps image URL of the route instead of directions." name:"static-map"`
+	StaticMapKey  bool       `help:"Include the API key in the --static-map URL." name:"static-map-key"`
+	TransitMode   []string   `help:"With --mode transit: bus, subway, train, tram, or rail. Repeatable." name:"transit-mode"`
+	TransitPref   string     `help:"With --mode transit: less_walking or fewer_transfers." name:"transit-preference"`
+	// NoModeDefaults maps to goplaces.DirectionsRequest.NoModeDefaults.
+	NoModeDefaults bool `help:"Skip per-mode defaults (transit departs now; drive with --now uses best_guess traffic)." name:"no-mode-defaults"`
+	FromHeading    *int `help:"With --mode drive and --from-lat/--from-lng: degrees (0-359) the vehicle faces, so the route starts that way." name:"from-heading"`
+	// Format csv writes one row per step, for spreadsheets; gpx is a track
+	// for GPS devices and geojson one for web maps.
+	Format string `help:"Output format: text, csv (one row per step; a leading mode column with --compare), gpx (GPX 1.1 track), or geojson (route LineString and step Points)." enum:"text,csv,gpx,geojson" default:"text"`
+	// CSV, GPX, and GeoJSON are hidden aliases for --format csv|gpx|geojson.
+	CSV     bool `help:"Alias for --format csv." hidden:""`
+	GPX     bool `help:"Alias for --format gpx." hidden:""`
+	GeoJSON bool `help:"Alias for --format geojson." name:"geojson" hidden:""`
+	// DepartIn and DepartAt are alternatives to --now; DepartAt is RFC 3339.
+	DepartIn *time.Duration `help:"Depart this long from now, e.g. 15m or 2h (transit and drive only)." name:"depart-in"`
+	DepartAt *time.Time     `help:"Depart at this time (RFC 3339; transit and drive only)." name:"depart-at"`
+	// Raw is for debugging: Google's body exactly as received.
+	Raw bool `help:"Print Google's raw JSON response instead of directions (ignores --json and --format)."`
+}
+
+// directionsComparison labels both routes so JSON consumers need not rely on order.
+type directionsComparison struct {
+	Primary goplaces.DirectionsResponse `json:"primary"`
+	Compare goplaces.DirectionsResponse `json:"compare"`
+	Diff    *goplaces.DirectionsDiff    `json:"diff,omitempty"`
+}
+
+// directionsRoundTrip pairs the outbound and return legs with combined totals.
+type directionsRoundTrip struct {
+	Outbound             goplaces.DirectionsResponse `json:"outbound"`
+	Return               goplaces.DirectionsResponse `json:"return"`
+	TotalDistanceMeters  int                         `json:"total_distance_meters"`
+	TotalDurationSeconds int                         `json:"total_duration_seconds"`
+}
+
+// applyFormatAlias sets Format from --csv, --gpx, or --geojson; at most one
+// alias may be given, and only with the default or the same --format.
+func (c *DirectionsCmd) applyFormatAlias() error {
+	alias := ""
+	for format, set := range map[string]bool{formatCSV: c.CSV, formatGPX: c.GPX, formatGeoJSON: c.GeoJSON} {
+		if !set {
+			continue
+		}
+		if alias != "" {
+			return goplaces.ValidationError{Field: "format", Message: "use only one of --csv, --gpx, or --geojson"}
+		}
+		alias = format
+	}
+	if alias == "" {
+		return nil
+	}
+	if c.Format != "" && c.Format != "text" && c.Format != alias {
+		return goplaces.ValidationError{Field: "format", Message: "--" + alias + " conflicts with --format " + c.Format}
+	}
+	c.Format = alias
+	return nil
 }
 
 // Run executes the directions command.
@@ -42,15 +118,49 @@ func (c *DirectionsCmd) Run(app *App) error {
 		}
 	}
 
+	returnMode := ""
+	if strings.TrimSpace(c.ReturnMode) != "" {
+		returnMode = normalizeDirectionsMode(c.ReturnMode)
+		if returnMode == "" {
+			return goplaces.ValidationError{Field: "return_mode", Message: "must be walk, drive, bicycle, or transit"}
+		}
+		if compareMode != "" {
+			return goplaces.ValidationError{Field: "return_mode", Message: "cannot be combined with --compare"}
+		}
+	}
+	if err := c.applyFormatAlias(); err != nil {
+		return err
+	}
+	if c.Diff && compareMode == "" {
+		return goplaces.ValidationError{Field: "diff", Message: "requires --compare"}
+	}
+	if c.Format != "text" && returnMode != "" {
+		return goplaces.ValidationError{Field: "format", Message: c.Format + " cannot be combined with --return-mode"}
+	}
+	if c.Raw && (compareMode != "" || returnMode != "") {
+		return goplaces.ValidationError{Field: "raw", Message: "cannot be combined with --compare or --return-mode"}
+	}
+	if c.Format == formatGPX && compareMode != "" {
+		return goplaces.ValidationError{Field: "format", Message: "gpx cannot be combined with --compare"}
+	}
+	departure, err := c.departureTime(app, primaryMode)
+	if err != nil {
+		return err
+	}
+
 	request := goplaces.DirectionsRequest{
-		From:        c.From,
-		To:          c.To,
-		FromPlaceID: c.FromPlaceID,
-		ToPlaceID:   c.ToPlaceID,
-		Mode:        primaryMode,
-		Units:       c.Units,
-		Language:    c.Language,
-		Region:      c.Region,
+		From:              c.From,
+		To:                c.To,
+		FromPlaceID:       c.FromPlaceID,
+		ToPlaceID:         c.ToPlaceID,
+		Mode:              primaryMode,
+		Units:             c.Units,
+		Region:            c.Region,
+		TrafficModel:      c.TrafficModel,
+		Waypoints:         c.Via,
+		OptimizeWaypoints: c.Optimize,
+		Avoid:             c.Avoid,
+		TransitModes:      c.TransitMode,
 	}
 	if c.FromLat != nil || c.FromLng != nil {
 		if c.FromLat == nil || c.FromLng == nil {
@@ -64,16 +174,62 @@ func (c *DirectionsCmd) Run(app *App) error {
 		}
 		request.ToLocation = &goplaces.LatLng{Lat: *c.ToLat, Lng: *c.ToLng}
 	}
+	request.DepartureTime = departure
+	request.ArrivalTime = c.ArriveBy
+	request.TransitRoutingPreference = c.TransitPref
+	request.NoModeDefaults = c.NoModeDefaults
+	request.FromHeading = c.FromHeading
 
-	response, err := app.client.Directions(context.Background(), request)
+	request, raw, response, err := c.directionsWithLanguageFallback(app, request)
 	if err != nil {
 		return err
 	}
+	if c.Raw {
+		_, err = app.out.Write(raw)
+		return err
+	}
+	if c.Strict {
+		if err := checkStrictMatch(response); err != nil {
+			return err
+		}
+	}
+	if c.NoBorderCross {
+		if err := c.checkBorders(app, response); err != nil {
+			return err
+		}
+	}
+
+	if c.StaticMap {
+		opts := response.StaticMapOptions()
+		opts.APIKey = app.apiKey
+		opts.SignWithKey = c.StaticMapKey
+		_, err := fmt.Fprintln(app.out, goplaces.StaticMapURL(opts))
+		return err
+	}
+
+	if returnMode != "" {
+		return c.runReturnTrip(app, request, response, returnMode)
+	}
 
 	var compareResponse *goplaces.DirectionsResponse
 	if compareMode != "" {
 		compareRequest := request
 		compareRequest.Mode = compareMode
+		// Pin the units the primary route resolved to (auto units included),
+		// so the two routes, their summaries, and --diff compare like for like.
+		compareRequest.Units = response.Units
+		if !supportsDepartureTime(compareMode) {
+			compareRequest.DepartureTime = nil
+		}
+		if compareMode != "transit" {
+			compareRequest.ArrivalTime = nil
+			compareRequest.TransitModes = nil
+			compareRequest.TransitRoutingPreference = ""
+		}
+		if compareMode != "driving" {
+			compareRequest.TrafficModel = ""
+			compareRequest.FromHeading = nil
+		}
 		second, err := app.client.Directions(context.Background(), compareRequest)
 		if err != nil {
 			return err
@@ -81,11 +237,49 @@ func (c *DirectionsCmd) Run(app *App) error {
 		compareResponse = &second
 	}
 
+	if !c.Quiet {
+		c.warnLongWalk(app, response)
+		if compareResponse != nil {
+			c.warnLongWalk(app, *compareResponse)
+		}
+	}
+
+	var diff *goplaces.DirectionsDiff
+	if c.Diff && compareResponse != nil {
+		delta := goplaces.DiffDirections(response, *compareResponse)
+		diff = &delta
+	}
+
+	if c.Format == formatGPX {
+		document, err := renderGPX(response)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(app.out, document)
+		return err
+	}
+	if c.Format == formatGeoJSON {
+		routes := []goplaces.DirectionsResponse{response}
+		if compareResponse != nil {
+			routes = append(routes, *compareResponse)
+		}
+		collection, err := directionsGeoJSON(routes...)
+		if err != nil {
+			return err
+		}
+		return app.writeJSON(collection)
+	}
+	if c.Format == formatCSV {
+		if compareResponse != nil {
+			return writeDirectionsCSV(app.out, app.numbers, true, response, *compareResponse)
+		}
+		return writeDirectionsCSV(app.out, app.numbers, false, response)
+	}
 	if app.json {
 		if compareResponse != nil {
-			return writeJSON(app.out, []goplaces.DirectionsResponse{response, *compareResponse})
+			return app.writeJSON(directionsComparison{Primary: response, Compare: *compareResponse, Diff: diff})
 		}
-		return writeJSON(app.out, response)
+		return app.writeJSON(response)
 	}
 
 	if compareResponse != nil {
@@ -94,6 +288,15 @@ func (c *DirectionsCmd) Run(app *App) error {
 			return err
 		}
 		_, err = app.out.Write([]byte("\n\n" + renderDirections(app.color, *compareResponse, c.Steps)))
+		if err != nil {
+			return err
+		}
+		summaries := goplaces.DirectionsResult{response, *compareResponse}.Summaries()
+		_, err = app.out.Write([]byte("\n\n" + renderRouteSummaries(app.color, summaries)))
+		if err != nil || diff == nil {
+			return err
+		}
+		_, err = app.out.Write([]byte("\n\n" + renderDirectionsDiff(app.color, app.numbers, response, *compareResponse, *diff)))
 		return err
 	}
 
@@ -101,6 +304,174 @@ func (c *DirectionsCmd) Run(app *App) error {
 	return err
 }
 
+// directionsWithLanguageFallback tries each --language in turn until a route
+// comes back with step instructions; if none does, the last one is kept. The
+// returned request carries the language used, so --compare and --return-mode
+// follow it, and raw is Google's body for --raw.
+func (c *DirectionsCmd) directionsWithLanguageFallback(
+	app *App,
+	request goplaces.DirectionsRequest,
+) (goplaces.DirectionsRequest, []byte, goplaces.DirectionsResponse, error) {
+	var languages []string
+	for _, language := range strings.Split(c.Language, ",") {
+		if language = strings.TrimSpace(language); language != "" {
+			languages = append(languages, language)
+		}
+	}
+	if len(languages) == 0 {
+		languages = []string{""}
+	}
+
+	var raw []byte
+	var response goplaces.DirectionsResponse
+	for i, language := range languages {
+		request.Language = language
+		var err error
+		raw, response, err = app.client.DirectionsRaw(context.Background(), request)
+		if err != nil {
+			return request, nil, goplaces.DirectionsResponse{}, err
+		}
+		if hasStepInstructions(response) || i == len(languages)-1 {
+			break
+		}
+		if !c.Quiet {
+			_, _ = fmt.Fprintf(app.err, "Note: no step instructions for --language %s; trying %s.\n", language, languages[i+1])
+		}
+	}
+	return request, raw, response, nil
+}
+
+func hasStepInstructions(response goplaces.DirectionsResponse) bool {
+	for _, step := range response.Steps {
+		if strings.TrimSpace(step.Instruction) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// departureTime resolves --now, --depart-in, and --depart-at, at most one of
+// which may be set. Relative times count from the app clock.
+func (c *DirectionsCmd) departureTime(app *App, mode string) (*time.Time, error) {
+	set := 0
+	for _, flag := range []bool{c.Now, c.DepartIn != nil, c.DepartAt != nil} {
+		if flag {
+			set++
+		}
+	}
+	if set > 1 {
+		return nil, goplaces.ValidationError{Field: "departure_time", Message: "use only one of --now, --depart-in, or --depart-at"}
+	}
+	switch {
+	case c.Now:
+		if !supportsDepartureTime(mode) {
+			return nil, goplaces.ValidationError{Field: "now", Message: "only applies to transit or drive"}
+		}
+		now := app.now()
+		return &now, nil
+	case c.DepartIn != nil:
+		if !supportsDepartureTime(mode) {
+			return nil, goplaces.ValidationError{Field: "depart_in", Message: "only applies to transit or drive"}
+		}
+		if *c.DepartIn < 0 {
+			return nil, goplaces.ValidationError{Field: "depart_in", Message: "must not be negative"}
+		}
+		departure := app.now().Add(*c.DepartIn)
+		return &departure, nil
+	case c.DepartAt != nil:
+		if !supportsDepartureTime(mode) {
+			return nil, goplaces.ValidationError{Field: "depart_at", Message: "only applies to transit or drive"}
+		}
+		return c.DepartAt, nil
+	}
+	return nil, nil
+}
+
+// runReturnTrip fetches the return leg (destination back to origin) and prints both legs.
+func (c *DirectionsCmd) runReturnTrip(
+	app *App,
+	outboundRequest goplaces.DirectionsRequest,
+	outbound goplaces.DirectionsResponse,
+	returnMode string,
+) error {
+	returnRequest := reverseDirectionsRequest(outboundRequest)
+	returnRequest.Mode = returnMode
+	// --now describes the outbound departure; the return time is unknown, so
+	// a transit return must not default to departing now either.
+	returnRequest.DepartureTime = nil
+	returnRequest.ArrivalTime = nil
+	returnRequest.NoModeDefaults = true
+	if returnMode != "transit" {
+		returnRequest.TransitModes = nil
+		returnRequest.TransitRoutingPreference = ""
+	}
+	returnRequest.TrafficModel = ""
+	back, err := app.client.Directions(context.Background(), returnRequest)
+	if err != nil {
+		return err
+	}
+
+	if !c.Quiet {
+		c.warnLongWalk(app, outbound)
+		c.warnLongWalk(app, back)
+	}
+
+	trip := directionsRoundTrip{
+		Outbound:             outbound,
+		Return:               back,
+		TotalDistanceMeters:  outbound.TotalDistanceMeters() + back.TotalDistanceMeters(),
+		TotalDurationSeconds: outbound.TotalDurationSeconds() + back.TotalDurationSeconds(),
+	}
+	if app.json {
+		return app.writeJSON(trip)
+	}
+	_, err = app.out.Write([]byte(renderRoundTrip(app.color, app.numbers, trip, c.Steps)))
+	return err
+}
+
+// reverseDirectionsRequest swaps origin and destination.
+func reverseDirectionsRequest(req goplaces.DirectionsRequest) goplaces.DirectionsRequest {
+	req.From, req.To = req.To, req.From
+	req.FromPlaceID, req.ToPlaceID = req.ToPlaceID, req.FromPlaceID
+	req.FromLocation, req.ToLocation = req.ToLocation, req.FromLocation
+	// The heading belongs to the old origin.
+	req.FromHeading = nil
+	if len(req.Waypoints) > 0 {
+		reversed := make([]string, len(req.Waypoints))
+		for i, waypoint := range req.Waypoints {
+			reversed[len(req.Waypoints)-1-i] = waypoint
+		}
+		req.Waypoints = reversed
+	}
+	return req
+}
+
+// writeDirectionsCSV writes one row per step. withMode adds a leading mode
+// column so compared routes can share one sheet.
+func writeDirectionsCSV(writer io.Writer, numbers numberFormat, withMode bool, routes ...goplaces.DirectionsResponse) error {
+	out := csv.NewWriter(writer)
+	out.Comma = numbers.csvComma()
+	header := []string{"instruction", "distance_meters", "duration_seconds", "travel_mode", "maneuver"}
+	if withMode {
+		header = append([]string{"mode"}, header...)
+	}
+	_ = out.Write(header)
+	for _, route := range routes {
+		for _, step := range route.Steps {
+			row := []string{
+				step.Instruction, strconv.Itoa(step.DistanceMeters), strconv.Itoa(step.DurationSeconds),
+				step.TravelMode, step.Maneuver,
+			}
+			if withMode {
+				row = append([]string{route.Mode}, row...)
+			}
+			_ = out.Write(row)
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
 func normalizeDirectionsMode(mode string) string {
 	switch strings.ToLower(strings.TrimSpace(mode)) {
 	case "walk", "walking":
@@ -115,3 +486,65 @@ func normalizeDirectionsMode(mode string) string {
 		return ""
 	}
 }
+
+// warnLongWalk mirrors the "that's a long walk" hint from Google Maps.
+func (c *DirectionsCmd) warnLongWalk(app *App, response goplaces.DirectionsResponse) {
+	if c.WalkWarnKm <= 0 || response.Mode != "WALKING" {
+		return
+	}
+	km := float64(response.DistanceMeters) / 1000
+	if km <= c.WalkWarnKm {
+		return
+	}
+	_, _ = fmt.Fprintf(app.err, "Note: walking route is %.1f km; consider --mode transit or --mode drive.\n", km)
+}
+
+// checkBorders reverse-geocodes points along the route and reports a country
+// change: an error with --strict, a stderr warning otherwise. Google cannot be
+// told to stay inside a country, so this can only check after the fact.
+func (c *DirectionsCmd) checkBorders(app *App, response goplaces.DirectionsResponse) error {
+	countries, err := app.client.RouteCountries(context.Background(), response, 0)
+	if err != nil {
+		if c.Strict {
+			return fmt.Errorf("check border crossings: %w", err)
+		}
+		_, _ = fmt.Fprintf(app.err, "warning: could not check border crossings: %v\n", err)
+		return nil
+	}
+	if len(countries) <= 1 {
+		return nil
+	}
+	message := fmt.Sprintf("route crosses a border (%s)", strings.Join(countries, " → "))
+	if c.Strict {
+		return errors.New(message)
+	}
+	_, _ = fmt.Fprintln(app.err, "warning: "+message)
+	return nil
+}
+
+// checkStrictMatch rejects routes whose endpoints Google only partially
+// geocoded, which usually means a vague input resolved somewhere unexpected.
+func checkStrictMatch(response goplaces.DirectionsResponse) error {
+	waypoints := response.GeocodedWaypoints
+	if len(waypoints) == 0 {
+		return nil
+	}
+	if waypoints[0].PartialMatch {
+		return goplaces.ValidationError{Field: "from", Message: weakMatchMessage(response.StartAddress)}
+	}
+	if len(waypoints) > 1 && waypoints[len(waypoints)-1].PartialMatch {
+		return goplaces.ValidationError{Field: "to", Message: weakMatchMessage(response.EndAddress)}
+	}
+	return nil
+}
+
+func weakMatchMessage(resolved string) string {
+	if strings.TrimSpace(resolved) == "" {
+		return "only partially matched; be more specific or use a place ID"
+	}
+	return fmt.Sprintf("only partially matched (resolved to %q); be more specific or use a place ID", resolved)
+}
+
+func supportsDepartureTime(mode string) bool {
+	return mode == "transit" || mode == "driving"
+}
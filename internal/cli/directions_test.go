@@ -0,0 +1,763 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/goplaces"
+)
+
+const directionsOKResponse = `{
+	"status": "OK",
+	"routes": [{
+		"summary": "Main",
+		"legs": [{
+			"distance": {"text": "1 km", "value": 1000},
+			"duration": {"text": "10 mins", "value": 600},
+			"start_address": "Start",
+			"end_address": "End",
+			"steps": [{
+				"html_instructions": "Head <b>north</b>",
+				"distance": {"text": "0.2 km", "value": 200},
+				"duration": {"text": "2 mins", "value": 120},
+				"travel_mode": "WALKING"
+			}]
+		}]
+	}]
+}`
+
+func TestRunDirectionsNow(t *testing.T) {
+	before := time.Now().Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw := r.URL.Query().Get("departure_time")
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			t.Fatalf("unexpected departure_time: %q", raw)
+		}
+		if value < before || value > time.Now().Unix() {
+			t.Fatalf("departure_time out of range: %d", value)
+		}
+		_, _ = w.Write([]byte(directionsOKResponse))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"directions",
+		"--from", "A",
+		"--to", "B",
+		"--mode", "transit",
+		"--now",
+		"--api-key", "test-key",
+		"--directions-base-url", server.URL,
+	}, &stdout, &stderr)
+
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stdout=%s stderr=%s)", exitCode, stdout.String(), stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Directions") {
+		t.Fatalf("unexpected stdout: %s", stdout.String())
+	}
+}
+
+func TestRunDirectionsNowRejectsWalking(t *testing.T) {
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"directions",
+		"--from", "A",
+		"--to", "B",
+		"--now",
+		"--api-key", "test-key",
+	}, &stdout, &stderr)
+
+	if exitCode != 2 {
+		t.Fatalf("expected validation error exit code 2, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), "now") {
+		t.Fatalf("unexpected stderr: %s", stderr.String())
+	}
+}
+
+func TestRunDirectionsLongWalkWarning(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(strings.Replace(directionsOKResponse, `"value": 1000`, `"value": 12500`, 1)))
+	}))
+	defer server.Close()
+
+	args := []string{
+		"directions",
+		"--from", "A",
+		"--to", "B",
+		"--api-key", "test-key",
+		"--directions-base-url", server.URL,
+	}
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	if exitCode := Run(args, &stdout, &stderr); exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "walking route is 12.5 km") {
+		t.Fatalf("expected long walk advisory, got: %s", stderr.String())
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	if exitCode := Run(append(args, "--walk-warn-km", "20"), &stdout, &stderr); exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d", exitCode)
+	}
+	if stderr.Len() != 0 {
+		t.Fatalf("expected no advisory under threshold, got: %s", stderr.String())
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	if exitCode := Run(append(args, "--quiet"), &stdout, &stderr); exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d", exitCode)
+	}
+	if stderr.Len() != 0 {
+		t.Fatalf("expected quiet stderr, got: %s", stderr.String())
+	}
+}
+
+func TestRunDirectionsCompareJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(directionsOKResponse))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"directions",
+		"--from", "A",
+		"--to", "B",
+		"--compare", "drive",
+		"--api-key", "test-key",
+		"--directions-base-url", server.URL,
+		"--json",
+	}, &stdout, &stderr)
+
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	var payload map[string]goplaces.DirectionsResponse
+	if err := json.Unmarshal(stdout.Bytes(), &payload); err != nil {
+		t.Fatalf("decode output: %v (%s)", err, stdout.String())
+	}
+	if payload["primary"].Mode != "WALKING" {
+		t.Fatalf("unexpected primary: %#v", payload["primary"])
+	}
+	if payload["compare"].Mode != "DRIVING" {
+		t.Fatalf("unexpected compare: %#v", payload["compare"])
+	}
+}
+
+func TestRunDirectionsCompareUnits(t *testing.T) {
+	var units []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		units = append(units, r.URL.Query().Get("units"))
+		body := directionsOKResponse
+		if r.URL.Query().Get("units") == "imperial" {
+			body = strings.ReplaceAll(body, `"1 km"`, `"0.6 mi"`)
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	exitCode := Run([]string{
+		"directions", "--from", "A", "--to", "B", "--compare", "drive", "--units=imperial", "--no-color",
+		"--api-key", "test-key", "--directions-base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if len(units) != 2 || units[0] != "imperial" || units[1] != "imperial" {
+		t.Fatalf("expected both requests in imperial, got %v", units)
+	}
+	if got := strings.Count(stdout.String(), "Distance: 0.6 mi (imperial)"); got != 2 {
+		t.Fatalf("expected both routes in miles, got %d:\n%s", got, stdout.String())
+	}
+}
+
+func TestRunDirectionsLanguageFallback(t *testing.T) {
+	var languages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		language := r.URL.Query().Get("language")
+		languages = append(languages, language)
+		body := directionsOKResponse
+		if language == "gsw" {
+			body = strings.ReplaceAll(body, "Head <b>north</b>", "")
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	exitCode := Run([]string{
+		"directions", "--from", "A", "--to", "B", "--language", "gsw, en", "--steps", "--no-color",
+		"--api-key", "test-key", "--directions-base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if len(languages) != 2 || languages[0] != "gsw" || languages[1] != "en" {
+		t.Fatalf("unexpected language requests: %v", languages)
+	}
+	if !strings.Contains(stdout.String(), "1. Head north") {
+		t.Fatalf("expected the second language's steps, got %s", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "trying en") {
+		t.Fatalf("expected a fallback note, got %q", stderr.String())
+	}
+}
+
+func TestRunDirectionsRaw(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(directionsOKResponse))
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	exitCode := Run([]string{
+		"directions", "--from", "A", "--to", "B", "--raw", "--json",
+		"--api-key", "test-key", "--directions-base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if stdout.String() != directionsOKResponse || !strings.Contains(stdout.String(), `"html_instructions": "Head <b>north</b>"`) {
+		t.Fatalf("expected the untouched body, got %s", stdout.String())
+	}
+
+	if exitCode := Run([]string{"directions", "--from", "A", "--to", "B", "--raw", "--compare", "drive", "--api-key", "test-key"}, &stdout, &stderr); exitCode != 2 {
+		t.Fatalf("expected exit code 2 with --compare, got %d", exitCode)
+	}
+}
+
+func TestRunDirectionsDiff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("mode") == "driving" {
+			_, _ = w.Write([]byte(strings.ReplaceAll(directionsOKResponse, "Head <b>north</b>", "Take the highway")))
+			return
+		}
+		_, _ = w.Write([]byte(directionsOKResponse))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"directions",
+		"--from", "A",
+		"--to", "B",
+		"--compare", "drive",
+		"--diff",
+		"--api-key", "test-key",
+		"--directions-base-url", server.URL,
+	}, &stdout, &stderr)
+
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	output := stdout.String()
+	if !strings.Contains(output, "Diff (WALKING → DRIVING)") || !strings.Contains(output, "+0 min") {
+		t.Fatalf("unexpected output: %s", output)
+	}
+	if !strings.Contains(output, "  - 1. Head north") || !strings.Contains(output, "  + 1. Take the highway") {
+		t.Fatalf("missing step diff: %s", output)
+	}
+
+	stdout.Reset()
+	exitCode = Run([]string{
+		"directions",
+		"--from", "A",
+		"--to", "B",
+		"--compare", "drive",
+		"--diff",
+		"--api-key", "test-key",
+		"--directions-base-url", server.URL,
+		"--json",
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	var payload struct {
+		Diff *goplaces.DirectionsDiff `json:"diff"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &payload); err != nil {
+		t.Fatalf("decode output: %v (%s)", err, stdout.String())
+	}
+	if payload.Diff == nil || len(payload.Diff.OnlyInB) != 1 {
+		t.Fatalf("unexpected diff: %#v", payload.Diff)
+	}
+}
+
+func TestRunDirectionsDiffRequiresCompare(t *testing.T) {
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"directions",
+		"--from", "A",
+		"--to", "B",
+		"--diff",
+		"--api-key", "test-key",
+	}, &stdout, &stderr)
+
+	if exitCode != 2 {
+		t.Fatalf("expected exit code 2, got %d", exitCode)
+	}
+}
+
+func TestRunDirectionsReturnMode(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		requests = append(requests, query.Get("mode")+":"+query.Get("origin")+">"+query.Get("destination"))
+		if query.Get("departure_time") != "" && query.Get("mode") == "transit" {
+			t.Fatalf("return leg should not inherit --now")
+		}
+		_, _ = w.Write([]byte(directionsOKResponse))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	exitCode := Run([]string{
+		"directions",
+		"--from", "Home",
+		"--to", "Office",
+		"--mode", "drive",
+		"--now",
+		"--return-mode", "transit",
+		"--api-key", "test-key",
+		"--directions-base-url", server.URL,
+	}, &stdout, &stderr)
+
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if len(requests) != 2 || requests[0] != "driving:Home>Office" || requests[1] != "transit:Office>Home" {
+		t.Fatalf("unexpected requests: %#v", requests)
+	}
+	if !strings.Contains(stdout.String(), "Round trip (DRIVING + TRANSIT)") || !strings.Contains(stdout.String(), "2.0 km") {
+		t.Fatalf("unexpected output: %s", stdout.String())
+	}
+
+	stdout.Reset()
+	exitCode = Run([]string{
+		"directions",
+		"--from", "Home",
+		"--to", "Office",
+		"--mode", "bicycle",
+		"--return-mode", "transit",
+		"--api-key", "test-key",
+		"--directions-base-url", server.URL,
+		"--json",
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	var trip directionsRoundTrip
+	if err := json.Unmarshal(stdout.Bytes(), &trip); err != nil {
+		t.Fatalf("decode output: %v (%s)", err, stdout.String())
+	}
+	if trip.TotalDistanceMeters != 2000 || trip.TotalDurationSeconds != 1200 || trip.Return.Mode != "TRANSIT" {
+		t.Fatalf("unexpected trip: %#v", trip)
+	}
+}
+
+func TestRunDirectionsViaReturnReversed(t *testing.T) {
+	var waypoints []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		waypoints = append(waypoints, r.URL.Query().Get("waypoints"))
+		_, _ = w.Write([]byte(directionsOKResponse))
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	exitCode := Run([]string{
+		"directions", "--from", "Home", "--to", "Office", "--via", "Bakery", "--via", "Gym", "--optimize",
+		"--return-mode", "walk", "--api-key", "test-key", "--directions-base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if len(waypoints) != 2 || waypoints[0] != "optimize:true|Bakery|Gym" || waypoints[1] != "optimize:true|Gym|Bakery" {
+		t.Fatalf("unexpected waypoints: %#v", waypoints)
+	}
+}
+
+func TestRunDirectionsCSV(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(directionsOKResponse))
+	}))
+	defer server.Close()
+
+	args := []string{
+		"directions", "--from", "A", "--to", "B", "--format", "csv",
+		"--api-key", "test-key", "--directions-base-url", server.URL,
+	}
+	var stdout, stderr bytes.Buffer
+	if exitCode := Run(args, &stdout, &stderr); exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	want := "instruction,distance_meters,duration_seconds,travel_mode,maneuver\nHead north,200,120,WALKING,\n"
+	if stdout.String() != want {
+		t.Fatalf("unexpected csv: %q", stdout.String())
+	}
+
+	stdout.Reset()
+	if exitCode := Run(append(args, "--compare", "drive"), &stdout, &stderr); exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "mode,instruction,") || lines[2] != "DRIVING,Head north,200,120,WALKING," {
+		t.Fatalf("unexpected compare csv: %q", stdout.String())
+	}
+
+	if exitCode := Run(append(args, "--return-mode", "walk"), &stdout, &stderr); exitCode != 2 {
+		t.Fatalf("expected exit code 2 with --return-mode, got %d", exitCode)
+	}
+}
+
+func TestRunDirectionsFormatAliases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"overview_polyline": {"points": "_p~iF~ps|U"}, "legs": [{"steps": [{"html_instructions": "Head north", "polyline": {"points": "_p~iF~ps|U"}}]}]}]}`))
+	}))
+	defer server.Close()
+
+	run := func(extra ...string) (int, string) {
+		args := append([]string{"directions", "--from", "A", "--to", "B", "--api-key", "test-key", "--directions-base-url", server.URL}, extra...)
+		var stdout, stderr bytes.Buffer
+		code := Run(args, &stdout, &stderr)
+		return code, stdout.String()
+	}
+	for _, format := range []string{"csv", "gpx", "geojson"} {
+		wantCode, want := run("--format", format)
+		code, got := run("--" + format)
+		if wantCode != 0 || code != 0 || got != want {
+			t.Fatalf("--%s: exit %d, want output of --format %s (exit %d)\n%s", format, code, format, wantCode, got)
+		}
+		if code, _ := run("--"+format, "--format", format); code != 0 {
+			t.Fatalf("--%s with the same --format: expected exit 0, got %d", format, code)
+		}
+	}
+	if code, _ := run("--csv", "--gpx"); code != 2 {
+		t.Fatalf("expected exit code 2 for two aliases, got %d", code)
+	}
+	if code, _ := run("--csv", "--format", "geojson"); code != 2 {
+		t.Fatalf("expected exit code 2 for a conflicting --format, got %d", code)
+	}
+}
+
+func TestRunDirectionsGPX(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"summary": "I-5", "overview_polyline": {"points": "_p~iF~ps|U_ulLnnqC_mqNvxq` + "`" + `@"}, "legs": [{"steps": [
+			{"html_instructions": "Head <b>north</b>", "polyline": {"points": "_p~iF~ps|U"}},
+			{"html_instructions": "Turn left", "maneuver": "turn-left", "polyline": {"points": "_p~iF~ps|U"}},
+			{"html_instructions": "No geometry"}
+		]}]}]}`))
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	exitCode := Run([]string{
+		"directions", "--from", "A", "--to", "B", "--format", "gpx",
+		"--api-key", "test-key", "--directions-base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	var doc struct {
+		Version   string `xml:"version,attr"`
+		Waypoints []struct {
+			Lat  float64 `xml:"lat,attr"`
+			Type string  `xml:"type"`
+		} `xml:"wpt"`
+		Points []struct {
+			Lat float64 `xml:"lat,attr"`
+			Lon float64 `xml:"lon,attr"`
+		} `xml:"trk>trkseg>trkpt"`
+	}
+	if err := xml.Unmarshal(stdout.Bytes(), &doc); err != nil {
+		t.Fatalf("decode gpx: %v (%s)", err, stdout.String())
+	}
+	if doc.Version != "1.1" || len(doc.Points) != 3 || doc.Points[2].Lat != 43.252 || doc.Points[2].Lon != -126.453 {
+		t.Fatalf("unexpected track: %#v", doc)
+	}
+	if len(doc.Waypoints) != 2 || doc.Waypoints[0].Lat != 38.5 || doc.Waypoints[1].Type != "turn-left" {
+		t.Fatalf("unexpected waypoints: %#v", doc.Waypoints)
+	}
+
+	if exitCode := Run([]string{"directions", "--from", "A", "--to", "B", "--format", "gpx", "--compare", "drive", "--api-key", "test-key"}, &stdout, &stderr); exitCode != 2 {
+		t.Fatalf("expected exit code 2 with --compare, got %d", exitCode)
+	}
+}
+
+func TestRunDirectionsGeoJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"overview_polyline": {"points": "_p~iF~ps|U_ulLnnqC_mqNvxq` + "`" + `@"}, "legs": [{
+			"distance": {"value": 1000}, "duration": {"value": 600},
+			"steps": [
+				{"html_instructions": "Head north", "travel_mode": "WALKING", "polyline": {"points": "_p~iF~ps|U"}},
+				{"html_instructions": "No geometry"}
+			]
+		}]}]}`))
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	exitCode := Run([]string{
+		"directions", "--from", "A", "--to", "B", "--format", "geojson", "--compare", "drive",
+		"--api-key", "test-key", "--directions-base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	var collection struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Geometry struct {
+				Type        string          `json:"type"`
+				Coordinates json.RawMessage `json:"coordinates"`
+			} `json:"geometry"`
+			Properties map[string]any `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &collection); err != nil {
+		t.Fatalf("decode geojson: %v (%s)", err, stdout.String())
+	}
+	if collection.Type != "FeatureCollection" || len(collection.Features) != 4 {
+		t.Fatalf("expected a line and a step point per route, got %s", stdout.String())
+	}
+	line := collection.Features[0]
+	var coordinates [][2]float64
+	if err := json.Unmarshal(line.Geometry.Coordinates, &coordinates); err != nil {
+		t.Fatalf("decode coordinates: %v", err)
+	}
+	if line.Geometry.Type != "LineString" || len(coordinates) != 3 || coordinates[0] != [2]float64{-120.2, 38.5} {
+		t.Fatalf("unexpected line: %s", stdout.String())
+	}
+	if step := collection.Features[1]; step.Geometry.Type != "Point" || step.Properties["instruction"] != "Head north" {
+		t.Fatalf("unexpected step point: %s", stdout.String())
+	}
+	if compare := collection.Features[2]; compare.Properties["mode"] != "DRIVING" {
+		t.Fatalf("expected the compared route second, got %s", stdout.String())
+	}
+}
+
+func TestRenderGPXRequiresPolyline(t *testing.T) {
+	if _, err := renderGPX(goplaces.DirectionsResponse{Mode: "WALKING"}); err == nil {
+		t.Fatalf("expected error without a polyline")
+	}
+}
+
+func TestRunDirectionsReturnModeValidation(t *testing.T) {
+	for _, args := range [][]string{
+		{"--return-mode", "teleport"},
+		{"--return-mode", "transit", "--compare", "drive"},
+	} {
+		var stdout bytes.Buffer
+		var stderr bytes.Buffer
+		exitCode := Run(append([]string{"directions", "--from", "A", "--to", "B", "--api-key", "test-key"}, args...), &stdout, &stderr)
+		if exitCode != 2 {
+			t.Fatalf("expected exit code 2 for %v, got %d", args, exitCode)
+		}
+	}
+}
+
+func TestRunDirectionsNoBorderCross(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/geocode") {
+			country := "DE"
+			if strings.HasPrefix(r.URL.Query().Get("latlng"), "47") {
+				country = "CH"
+			}
+			_, _ = w.Write([]byte(`{"status": "OK", "results": [{"address_components": [{"short_name": "` + country + `", "types": ["country"]}]}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{
+			"distance": {"value": 1000}, "duration": {"value": 600},
+			"start_location": {"lat": 48.1, "lng": 11.5}, "end_location": {"lat": 47.4, "lng": 8.5}
+		}]}]}`))
+	}))
+	defer server.Close()
+
+	args := []string{
+		"directions", "--from", "Munich", "--to", "Zurich", "--no-border-cross",
+		"--api-key", "test-key",
+		"--directions-base-url", server.URL + "/directions",
+		"--geocode-base-url", server.URL + "/geocode",
+	}
+	var stdout, stderr bytes.Buffer
+	if exitCode := Run(args, &stdout, &stderr); exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "warning: route crosses a border (DE → CH)") {
+		t.Fatalf("expected border warning, got %q", stderr.String())
+	}
+
+	stderr.Reset()
+	if exitCode := Run(append(args, "--strict"), &stdout, &stderr); exitCode != 1 {
+		t.Fatalf("expected exit code 1 with --strict, got %d", exitCode)
+	}
+}
+
+func TestRunDirectionsStaticMap(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"overview_polyline": {"points": "_p~iF~ps|U"}, "legs": [{
+			"start_location": {"lat": 38.5, "lng": -120.2}, "end_location": {"lat": 40.7, "lng": -120.95}
+		}]}]}`))
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	exitCode := Run([]string{
+		"directions", "--from", "A", "--to", "B", "--static-map", "--static-map-key",
+		"--api-key", "test-key", "--directions-base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	out := strings.TrimSpace(stdout.String())
+	if !strings.HasPrefix(out, goplaces.DefaultStaticMapBaseURL+"?") || strings.Contains(out, "\n") {
+		t.Fatalf("expected only the URL, got %q", out)
+	}
+	if !strings.Contains(out, "key=test-key") || !strings.Contains(out, "enc%3A_p~iF~ps%7CU") {
+		t.Fatalf("unexpected URL: %s", out)
+	}
+}
+
+func TestDirectionsNowUsesAppClock(t *testing.T) {
+	frozen := time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("departure_time"); got != strconv.FormatInt(frozen.Unix(), 10) {
+			t.Fatalf("unexpected departure_time: %s", got)
+		}
+		_, _ = w.Write([]byte(directionsOKResponse))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	app := &App{
+		client: goplaces.NewClient(goplaces.Options{APIKey: "test-key", DirectionsBaseURL: server.URL}),
+		out:    &stdout,
+		err:    &bytes.Buffer{},
+		color:  NewColor(false),
+		now:    func() time.Time { return frozen },
+	}
+	cmd := &DirectionsCmd{From: "A", To: "B", Mode: "transit", Now: true}
+	if err := cmd.Run(app); err != nil {
+		t.Fatalf("Run error: %v", err)
+	}
+}
+
+func TestRunDirectionsDepartIn(t *testing.T) {
+	earliest := time.Now().Add(30 * time.Minute).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw := r.URL.Query().Get("departure_time")
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || value < earliest || value > time.Now().Add(30*time.Minute).Unix() {
+			t.Fatalf("unexpected departure_time: %q", raw)
+		}
+		_, _ = w.Write([]byte(directionsOKResponse))
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	exitCode := Run([]string{
+		"directions", "--from", "A", "--to", "B", "--mode", "drive", "--depart-in=30m",
+		"--api-key", "test-key", "--directions-base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+}
+
+func TestRunDirectionsDepartAt(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("departure_time"); got != "1772353800" {
+			t.Fatalf("unexpected departure_time: %s", got)
+		}
+		_, _ = w.Write([]byte(directionsOKResponse))
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	exitCode := Run([]string{
+		"directions", "--from", "A", "--to", "B", "--mode", "transit", "--depart-at", "2026-03-01T09:30:00+01:00",
+		"--api-key", "test-key", "--directions-base-url", server.URL,
+	}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+}
+
+func TestRunDirectionsDepartureValidation(t *testing.T) {
+	for _, args := range [][]string{
+		{"--mode", "drive", "--now", "--depart-in", "15m"},
+		{"--mode", "drive", "--depart-in", "15m", "--depart-at", "2026-03-01T09:30:00Z"},
+		{"--mode", "drive", "--depart-in=-5m"},
+		{"--depart-in", "15m"},
+		{"--depart-at", "2026-03-01T09:30:00Z"},
+	} {
+		var stdout, stderr bytes.Buffer
+		exitCode := Run(append([]string{"directions", "--from", "A", "--to", "B", "--api-key", "test-key"}, args...), &stdout, &stderr)
+		if exitCode != 2 {
+			t.Fatalf("expected exit code 2 for %v, got %d (stderr=%s)", args, exitCode, stderr.String())
+		}
+	}
+}
+
+func TestRunDirectionsStrict(t *testing.T) {
+	partial := strings.Replace(directionsOKResponse, `"status": "OK",`, `"status": "OK",
+	"geocoded_waypoints": [
+		{"geocoder_status": "OK", "place_id": "a"},
+		{"geocoder_status": "OK", "partial_match": true, "place_id": "b"}
+	],`, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(partial))
+	}))
+	defer server.Close()
+
+	args := []string{
+		"directions",
+		"--from", "A",
+		"--to", "Springfield",
+		"--api-key", "test-key",
+		"--directions-base-url", server.URL,
+	}
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	if exitCode := Run(args, &stdout, &stderr); exitCode != 0 {
+		t.Fatalf("expected lenient default, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	exitCode := Run(append(args, "--strict"), &stdout, &stderr)
+	if exitCode != 2 {
+		t.Fatalf("expected exit code 2, got %d", exitCode)
+	}
+	if !strings.Contains(stderr.String(), `invalid to: only partially matched (resolved to "End")`) {
+		t.Fatalf("unexpected stderr: %s", stderr.String())
+	}
+}
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/steipete/goplaces"
+)
+
+const formatGeoJSON = "geojson"
+
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+// geoJSONFeature holds a geoJSONPoint or geoJSONLineString and one of the
+// *Fields structs; struct fields keep the key order stable.
+type geoJSONFeature struct {
+	Type       string `json:"type"`
+	Geometry   any    `json:"geometry"`
+	Properties any    `json:"properties"`
+}
+
+// geoJSONPoint holds [lng, lat]: GeoJSON (RFC 7946) puts longitude first.
+type geoJSONPoint struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+type geoJSONLineString struct {
+	Type        string       `json:"type"`
+	Coordinates [][2]float64 `json:"coordinates"`
+}
+
+func geoJSONLine(points []goplaces.LatLng) geoJSONLineString {
+	line := geoJSONLineString{Type: "LineString", Coordinates: make([][2]float64, 0, len(points))}
+	for _, point := range points {
+		line.Coordinates = append(line.Coordinates, [2]float64{point.Lng, point.Lat})
+	}
+	return line
+}
+
+type geoJSONPlaceFields struct {
+	Name    string   `json:"name,omitempty"`
+	Rating  *float64 `json:"rating,omitempty"`
+	Address string   `json:"address,omitempty"`
+	PlaceID string   `json:"place_id"`
+}
+
+// placesGeoJSON converts places to Point features. Places without a location
+// cannot be drawn and are skipped; the count is returned so callers can say so.
+func placesGeoJSON(places []goplaces.PlaceSummary) (geoJSONFeatureCollection, int) {
+	collection := geoJSONFeatureCollection{Type: "FeatureCollection", Features: []geoJSONFeature{}}
+	skipped := 0
+	for _, place := range places {
+		if place.Location == nil {
+			skipped++
+			continue
+		}
+		collection.Features = append(collection.Features, geoJSONFeature{
+			Type: "Feature",
+			Geometry: geoJSONPoint{
+				Type:        "Point",
+				Coordinates: [2]float64{place.Location.Lng, place.Location.Lat},
+			},
+			Properties: geoJSONPlaceFields{
+				Name:    place.Name,
+				Rating:  place.Rating,
+				Address: place.Address,
+				PlaceID: place.PlaceID,
+			},
+		})
+	}
+	return collection, skipped
+}
+
+type geoJSONRouteFields struct {
+	Mode            string `json:"mode,omitempty"`
+	Summary         string `json:"summary,omitempty"`
+	DistanceMeters  int    `json:"distance_meters"`
+	DurationSeconds int    `json:"duration_seconds"`
+}
+
+type geoJSONStepFields struct {
+	Mode        string `json:"mode,omitempty"`
+	Step        int    `json:"step"`
+	Instruction string `json:"instruction,omitempty"`
+	Maneuver    string `json:"maneuver,omitempty"`
+	TravelMode  string `json:"travel_mode,omitempty"`
+}
+
+// directionsGeoJSON converts routes to a LineString of the overview polyline
+// each, followed by a Point where each of its steps starts (steps without
+// geometry are skipped). Step numbers are 1-based, as in the text output.
+func directionsGeoJSON(routes ...goplaces.DirectionsResponse) (geoJSONFeatureCollection, error) {
+	collection := geoJSONFeatureCollection{Type: "FeatureCollection", Features: []geoJSONFeature{}}
+	for _, route := range routes {
+		if route.EncodedPolyline == "" {
+			return geoJSONFeatureCollection{}, errors.New("route has no overview polyline to export")
+		}
+		points, err := goplaces.DecodePolyline(route.EncodedPolyline)
+		if err != nil {
+			return geoJSONFeatureCollection{}, err
+		}
+		collection.Features = append(collection.Features, geoJSONFeature{
+			Type:     "Feature",
+			Geometry: geoJSONLine(points),
+			Properties: geoJSONRouteFields{
+				Mode:            route.Mode,
+				Summary:         route.Summary,
+				DistanceMeters:  route.DistanceMeters,
+				DurationSeconds: route.DurationSeconds,
+			},
+		})
+
+		for i, step := range route.Steps {
+			path, err := step.StepPath()
+			if err != nil {
+				return geoJSONFeatureCollection{}, err
+			}
+			if len(path) == 0 {
+				continue
+			}
+			collection.Features = append(collection.Features, geoJSONFeature{
+				Type:     "Feature",
+				Geometry: geoJSONPoint{Type: "Point", Coordinates: [2]float64{path[0].Lng, path[0].Lat}},
+				Properties: geoJSONStepFields{
+					Mode:        route.Mode,
+					Step:        i + 1,
+					Instruction: step.Instruction,
+					Maneuver:    step.Maneuver,
+					TravelMode:  step.TravelMode,
+				},
+			})
+		}
+	}
+	return collection, nil
+}
+
+type geoJSONRoutePathFields struct {
+	Labels          []string `json:"labels,omitempty"`
+	DistanceMeters  int      `json:"distance_meters,omitempty"`
+	DurationSeconds int      `json:"duration_seconds,omitempty"`
+}
+
+// routeGeoJSON converts a route search to a LineString per computed route
+// (just the default unless reference or alternative routes were requested),
+// followed by a Point per place found along it.
+func routeGeoJSON(response goplaces.RouteResponse) (geoJSONFeatureCollection, error) {
+	routes := response.Routes
+	if len(routes) == 0 {
+		routes = []goplaces.LabeledRoute{{EncodedPolyline: response.EncodedPolyline}}
+	}
+	collection := geoJSONFeatureCollection{Type: "FeatureCollection", Features: []geoJSONFeature{}}
+	for _, route := range routes {
+		points, err := goplaces.DecodePolyline(route.EncodedPolyline)
+		if err != nil {
+			return geoJSONFeatureCollection{}, err
+		}
+		collection.Features = append(collection.Features, geoJSONFeature{
+			Type:     "Feature",
+			Geometry: geoJSONLine(points),
+			Properties: geoJSONRoutePathFields{
+				Labels:          route.Labels,
+				DistanceMeters:  route.DistanceMeters,
+				DurationSeconds: route.DurationSeconds,
+			},
+		})
+	}
+
+	places := make([]goplaces.PlaceSummary, 0, len(response.Places))
+	for _, place := range response.Places {
+		places = append(places, place.PlaceSummary)
+	}
+	points, _ := placesGeoJSON(places)
+	collection.Features = append(collection.Features, points.Features...)
+	return collection, nil
+}
+
+// writePlacesGeoJSON prints a FeatureCollection, noting skipped places on stderr.
+func (a *App) writePlacesGeoJSON(places []goplaces.PlaceSummary) error {
+	collection, skipped := placesGeoJSON(places)
+	if skipped > 0 {
+		_, _ = fmt.Fprintf(a.err, "warning: %d place(s) without a location omitted from GeoJSON\n", skipped)
+	}
+	return a.writeJSON(collection)
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"encoding/xml"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/steipete/goplaces"
+)
+
+const formatGPX = "gpx"
+
+type gpxDocument struct {
+	XMLName   xml.Name      `xml:"gpx"`
+	Version   string        `xml:"version,attr"`
+	Creator   string        `xml:"creator,attr"`
+	Namespace string        `xml:"xmlns,attr"`
+	Waypoints []gpxWaypoint `xml:"wpt"`
+	Track     gpxTrack      `xml:"trk"`
+}
+
+// gpxWaypoint marks where a step starts; Type carries Google's maneuver.
+type gpxWaypoint struct {
+	Lat  string `xml:"lat,attr"`
+	Lon  string `xml:"lon,attr"`
+	Name string `xml:"name,omitempty"`
+	Type string `xml:"type,omitempty"`
+}
+
+type gpxTrack struct {
+	Name    string          `xml:"name,omitempty"`
+	Segment gpxTrackSegment `xml:"trkseg"`
+}
+
+type gpxTrackSegment struct {
+	Points []gpxPoint `xml:"trkpt"`
+}
+
+type gpxPoint struct {
+	Lat string `xml:"lat,attr"`
+	Lon string `xml:"lon,attr"`
+}
+
+// renderGPX builds a GPX 1.1 document for GPS devices: the overview polyline
+// as one track, plus a waypoint at the start of every step with geometry.
+func renderGPX(resp goplaces.DirectionsResponse) (string, error) {
+	if resp.EncodedPolyline == "" {
+		return "", errors.New("route has no overview polyline to export")
+	}
+	points, err := goplaces.DecodePolyline(resp.EncodedPolyline)
+	if err != nil {
+		return "", err
+	}
+
+	doc := gpxDocument{
+		Version:   "1.1",
+		Creator:   "goplaces",
+		Namespace: "http://www.topografix.com/GPX/1/1",
+		Track:     gpxTrack{Name: gpxTrackName(resp)},
+	}
+	for _, step := range resp.Steps {
+		path, err := step.StepPath()
+		if err != nil {
+			return "", err
+		}
+		if len(path) == 0 {
+			continue
+		}
+		lat, lon := gpxCoordinates(path[0])
+		doc.Waypoints = append(doc.Waypoints, gpxWaypoint{Lat: lat, Lon: lon, Name: step.Instruction, Type: step.Maneuver})
+	}
+	doc.Track.Segment.Points = make([]gpxPoint, 0, len(points))
+	for _, point := range points {
+		lat, lon := gpxCoordinates(point)
+		doc.Track.Segment.Points = append(doc.Track.Segment.Points, gpxPoint{Lat: lat, Lon: lon})
+	}
+
+	payload, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(payload) + "\n", nil
+}
+
+func gpxTrackName(resp goplaces.DirectionsResponse) string {
+	if summary := strings.TrimSpace(resp.Summary); summary != "" {
+		return summary
+	}
+	return resp.Mode
+}
+
+// gpxCoordinates keeps the shortest exact form; GPX wants dot decimals
+// whatever the --locale.
+func gpxCoordinates(point goplaces.LatLng) (string, string) {
+	return strconv.FormatFloat(point.Lat, 'f', -1, 64), strconv.FormatFloat(point.Lng, 'f', -1, 64)
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+const (
+	jsonStyleSnake = "snake"
+	jsonStyleCamel = "camel"
+)
+
+type jsonScope struct {
+	object bool
+	first  bool
+	atKey  bool
+}
+
+// camelCaseJSON rewrites object keys from snake_case to camelCase, keeping
+// key order and values (numbers included) byte-for-byte.
+func camelCaseJSON(payload []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var out bytes.Buffer
+	var stack []jsonScope
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) && len(stack) == 0 {
+			return out.Bytes(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		closing := token == json.Delim('}') || token == json.Delim(']')
+		isKey := false
+		if len(stack) > 0 && !closing {
+			scope := &stack[len(stack)-1]
+			switch {
+			case scope.object && scope.atKey:
+				if !scope.first {
+					out.WriteByte(',')
+				}
+				isKey = true
+			case scope.object:
+				out.WriteByte(':')
+			case !scope.first:
+				out.WriteByte(',')
+			}
+			if scope.object {
+				scope.atKey = !scope.atKey
+			}
+			scope.first = false
+		}
+
+		switch value := token.(type) {
+		case json.Delim:
+			out.WriteRune(rune(value))
+			switch value {
+			case '{':
+				stack = append(stack, jsonScope{object: true, first: true, atKey: true})
+			case '[':
+				stack = append(stack, jsonScope{first: true})
+			default:
+				stack = stack[:len(stack)-1]
+			}
+		case json.Number:
+			out.WriteString(value.String())
+		default:
+			if key, ok := value.(string); ok && isKey {
+				value = snakeToCamel(key)
+			}
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			out.Write(encoded)
+		}
+	}
+}
+
+func snakeToCamel(key string) string {
+	if !strings.Contains(key, "_") {
+		return key
+	}
+	parts := strings.Split(key, "_")
+	var out strings.Builder
+	out.WriteString(parts[0])
+	for _, part := range parts[1:] {
+		if part == "" {
+			continue
+		}
+		out.WriteString(strings.ToUpper(part[:1]))
+		out.WriteString(part[1:])
+	}
+	return out.String()
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCamelCaseJSON(t *testing.T) {
+	payload := []byte(`{"place_id":"a_b","user_rating_count":12,"nested":[{"max_charge_rate_kw":150.5},[]],"empty":{},"ok":true,"none":null}`)
+	got, err := camelCaseJSON(payload)
+	if err != nil {
+		t.Fatalf("camelCaseJSON error: %v", err)
+	}
+	want := `{"placeId":"a_b","userRatingCount":12,"nested":[{"maxChargeRateKw":150.5},[]],"empty":{},"ok":true,"none":null}`
+	if string(got) != want {
+		t.Fatalf("unexpected output:\n got %s\nwant %s", got, want)
+	}
+	if _, err := camelCaseJSON([]byte(`{"a":`)); err == nil {
+		t.Fatalf("expected error for truncated JSON")
+	}
+}
+
+func TestSnakeToCamel(t *testing.T) {
+	cases := map[string]string{
+		"place_id":       "placeId",
+		"name":           "name",
+		"trailing_":      "trailing",
+		"two__underscor": "twoUnderscor",
+	}
+	for input, want := range cases {
+		if got := snakeToCamel(input); got != want {
+			t.Fatalf("snakeToCamel(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestWriteJSONCamelStyle(t *testing.T) {
+	var out bytes.Buffer
+	app := &App{out: &out, jsonStyle: jsonStyleCamel}
+	if err := app.writeJSON(map[string]int{"distance_meters": 5}); err != nil {
+		t.Fatalf("writeJSON error: %v", err)
+	}
+	if out.String() != "{\n  \"distanceMeters\": 5\n}\n" {
+		t.Fatalf("unexpected camel output: %q", out.String())
+	}
+	if err := app.writeJSON(map[string]any{"bad": func() {}}); err == nil {
+		t.Fatalf("expected json error")
+	}
+}
+
+func TestRunJSONStyleCamel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"places": [{"id": "place-1", "displayName": {"text": "Cafe"}}]}`))
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	args := []string{"search", "coffee", "--api-key", "test-key", "--base-url", server.URL, "--json-compact", "--json-style", "camel"}
+	if exitCode := Run(args, &stdout, &stderr); exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if !strings.HasPrefix(stdout.String(), "[{\"placeId\":\"place-1\"") {
+		t.Fatalf("unexpected camel output: %q", stdout.String())
+	}
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/steipete/goplaces"
+)
+
+var localeTagPattern = regexp.MustCompile(`^[A-Za-z]{2,3}([-_][A-Za-z0-9]{2,8})*$`)
+
+// commaDecimalLanguages write 1,5 for one and a half. Regions that differ from
+// their language (de-CH, es-MX, ...) are listed in dotDecimalRegions.
+var commaDecimalLanguages = map[string]bool{
+	"bg": true, "ca": true, "cs": true, "da": true, "de": true, "el": true,
+	"es": true, "et": true, "fi": true, "fr": true, "hr": true, "hu": true,
+	"id": true, "it": true, "lt": true, "lv": true, "nb": true, "nl": true,
+	"nn": true, "no": true, "pl": true, "pt": true, "ro": true, "ru": true,
+	"sk": true, "sl": true, "sr": true, "sv": true, "tr": true, "uk": true,
+	"vi": true,
+}
+
+var dotDecimalRegions = map[string]bool{
+	"de-ch": true, "de-li": true, "fr-ch": true, "it-ch": true,
+	"es-mx": true, "es-us": true,
+}
+
+// numberFormat renders fractional numbers in text and CSV output. The zero
+// value is the C locale: dot decimals, comma-separated CSV.
+type numberFormat struct {
+	decimalComma bool
+}
+
+// parseNumberLocale accepts "C"/"POSIX" or a BCP-47 tag such as de-DE.
+func parseNumberLocale(tag string) (numberFormat, error) {
+	tag = strings.TrimSpace(tag)
+	switch strings.ToUpper(tag) {
+	case "", "C", "POSIX":
+		return numberFormat{}, nil
+	}
+	if !localeTagPattern.MatchString(tag) {
+		return numberFormat{}, goplaces.ValidationError{Field: "locale", Message: "must be C or a language tag like de-DE"}
+	}
+	tag = strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	language, rest, _ := strings.Cut(tag, "-")
+	region, _, _ := strings.Cut(rest, "-")
+	if dotDecimalRegions[language+"-"+region] {
+		return numberFormat{}, nil
+	}
+	return numberFormat{decimalComma: commaDecimalLanguages[language]}, nil
+}
+
+// float formats value with prec decimals (-1 for the shortest exact form).
+func (f numberFormat) float(value float64, prec int) string {
+	formatted := strconv.FormatFloat(value, 'f', prec, 64)
+	if f.decimalComma {
+		return strings.Replace(formatted, ".", ",", 1)
+	}
+	return formatted
+}
+
+// csvComma is the CSV field separator. Comma-decimal locales use ";", matching
+// what their spreadsheets expect on import.
+func (f numberFormat) csvComma() rune {
+	if f.decimalComma {
+		return ';'
+	}
+	return ','
+}
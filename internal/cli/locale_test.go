@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseNumberLocale(t *testing.T) {
+	cases := map[string]bool{
+		"":      false,
+		"C":     false,
+		"posix": false,
+		"en-US": false,
+		"de-DE": true,
+		"de_AT": true,
+		"fr":    true,
+		"de-CH": false,
+		"es-MX": false,
+		"pt-BR": true,
+	}
+	for tag, comma := range cases {
+		format, err := parseNumberLocale(tag)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tag, err)
+		}
+		if format.decimalComma != comma {
+			t.Fatalf("%q: expected decimalComma=%v", tag, comma)
+		}
+	}
+	if _, err := parseNumberLocale("de DE"); err == nil {
+		t.Fatalf("expected validation error")
+	}
+
+	german := numberFormat{decimalComma: true}
+	if got := german.float(12.345, 1); got != "12,3" {
+		t.Fatalf("unexpected float: %q", got)
+	}
+	if got := (numberFormat{}).float(-122.25, -1); got != "-122.25" {
+		t.Fatalf("unexpected float: %q", got)
+	}
+	if german.csvComma() != ';' || (numberFormat{}).csvComma() != ',' {
+		t.Fatalf("unexpected csv separators")
+	}
+}
+
+func TestRunValidateCSVLocale(t *testing.T) {
+	server := newValidateServer()
+	defer server.Close()
+	path := writeValidateFile(t)
+
+	var stdout, stderr bytes.Buffer
+	Run([]string{"validate", path, "--format", "csv", "--quiet", "--locale", "de-DE", "--api-key", "test-key", "--geocode-base-url", server.URL}, &stdout, &stderr)
+	if !strings.HasPrefix(stdout.String(), "line;input;status;address;lat;lng;place_id;candidates;reason\n2;1 Main St;OK;1 Main St, Town;1,5;2;;1;\n") {
+		t.Fatalf("unexpected csv: %q", stdout.String())
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	if exitCode := Run([]string{"validate", path, "--locale", "not a locale", "--api-key", "test-key"}, &stdout, &stderr); exitCode != 2 {
+		t.Fatalf("expected exit code 2, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/steipete/goplaces"
+)
+
+const formatNDJSON = "ndjson"
+
+// writeJSONLine writes value as one compact line, honoring --json-style.
+func (a *App) writeJSONLine(value any) error {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if a.jsonStyle == jsonStyleCamel {
+		if payload, err = camelCaseJSON(payload); err != nil {
+			return err
+		}
+	}
+	_, err = a.out.Write(append(payload, '\n'))
+	return err
+}
+
+// writeNDJSON writes one object per line for results that arrive in one response.
+func writeNDJSON[T any](app *App, items []T) error {
+	for _, item := range items {
+		if err := app.writeJSONLine(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamPlacesNDJSON writes places as the iterator yields them, following page
+// tokens, so memory stays flat however many pages a scan spans. --head stops
+// fetching early. It returns how many places were written.
+func (a *App) streamPlacesNDJSON(it *goplaces.Iterator[goplaces.PlaceSummary], evAvailable bool, head int) (int, error) {
+	written := 0
+	for head == 0 || written < head {
+		place, err := it.Next(context.Background())
+		if errors.Is(err, goplaces.ErrIteratorDone) {
+			break
+		}
+		if err != nil {
+			return written, err
+		}
+		if evAvailable && !hasFreeConnector(place) {
+			continue
+		}
+		if err := a.writeJSONLine(place); err != nil {
+			return written, err
+		}
+		written++
+	}
+	return written, nil
+}
@@ -3,13 +3,16 @@ package cli
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/steipete/goplaces"
 )
 
-func renderSearch(color Color, response goplaces.SearchResponse) string {
+func renderSearch(color Color, numbers numberFormat, response goplaces.SearchResponse) string {
 	var out bytes.Buffer
 	count := len(response.Results)
 	if count == 0 {
@@ -20,7 +23,7 @@ func renderSearch(color Color, response goplaces.SearchResponse) string {
 
 	for i, place := range response.Results {
 		out.WriteString(fmt.Sprintf("%d. %s\n", i+1, formatTitle(color, place.Name, place.Address)))
-		writePlaceSummary(&out, color, place)
+		writePlaceSummary(&out, color, numbers, place)
 		if i < count-1 {
 			out.WriteString("\n")
 		}
@@ -56,7 +59,7 @@ func renderAutocomplete(color Color, response goplaces.AutocompleteResponse) str
 	return out.String()
 }
 
-func renderNearby(color Color, response goplaces.NearbySearchResponse) string {
+func renderNearby(color Color, numbers numberFormat, response goplaces.NearbySearchResponse, typeDisplay bool) string {
 	var out bytes.Buffer
 	count := len(response.Results)
 	if count == 0 {
@@ -66,8 +69,12 @@ func renderNearby(color Color, response goplaces.NearbySearchResponse) string {
 	out.WriteString("\n")
 
 	for i, place := range response.Results {
-		out.WriteString(fmt.Sprintf("%d. %s\n", i+1, formatTitle(color, place.Name, place.Address)))
-		writePlaceSummary(&out, color, place)
+		name := place.Name
+		if category := placeCategory(place); typeDisplay && category != "" {
+			name = fmt.Sprintf("%s [%s]", name, category)
+		}
+		out.WriteString(fmt.Sprintf("%d. %s\n", i+1, formatTitle(color, name, place.Address)))
+		writePlaceSummary(&out, color, numbers, place)
 		if i < count-1 {
 			out.WriteString("\n")
 		}
@@ -83,6 +90,14 @@ func renderNearby(color Color, response goplaces.NearbySearchResponse) string {
 	return out.String()
 }
 
+// placeCategory prefers the localized primary type label over the raw type.
+func placeCategory(place goplaces.PlaceSummary) string {
+	if name := strings.TrimSpace(place.PrimaryTypeName); name != "" {
+		return name
+	}
+	return strings.TrimSpace(place.PrimaryType)
+}
+
 func renderPhoto(color Color, response goplaces.PhotoMediaResponse) string {
 	var out bytes.Buffer
 	out.WriteString(color.Bold("Photo"))
@@ -92,11 +107,11 @@ func renderPhoto(color Color, response goplaces.PhotoMediaResponse) string {
 	return out.String()
 }
 
-func renderDetails(color Color, place goplaces.PlaceDetails) string {
+func renderDetails(color Color, numbers numberFormat, place goplaces.PlaceDetails) string {
 	var out bytes.Buffer
 	out.WriteString(color.Bold(formatTitle(color, place.Name, place.Address)))
 	out.WriteString("\n")
-	writePlaceDetails(&out, color, place)
+	writePlaceDetails(&out, color, numbers, place)
 	return out.String()
 }
 
@@ -119,7 +134,7 @@ func renderResolve(color Color, response goplaces.LocationResolveResponse) strin
 	return out.String()
 }
 
-func renderRoute(color Color, response goplaces.RouteResponse) string {
+func renderRoute(color Color, numbers numberFormat, response goplaces.RouteResponse) string {
 	var out bytes.Buffer
 	count := len(response.Waypoints)
 	if count == 0 {
@@ -140,7 +155,7 @@ func renderRoute(color Color, response goplaces.RouteResponse) string {
 		} else {
 			for j, place := range waypoint.Results {
 				out.WriteString(fmt.Sprintf("%d. %s\n", j+1, formatTitle(color, place.Name, place.Address)))
-				writePlaceSummary(&out, color, place)
+				writePlaceSummary(&out, color, numbers, place)
 				if j < len(waypoint.Results)-1 {
 					out.WriteString("\n")
 				}
@@ -152,9 +167,64 @@ func renderRoute(color Color, response goplaces.RouteResponse) string {
 		}
 	}
 
+	if len(response.Places) > 0 {
+		out.WriteString("\n")
+		out.WriteString(color.Bold(fmt.Sprintf("Stops by detour (%d)", len(response.Places))))
+		out.WriteString("\n")
+		for i, place := range response.Places {
+			out.WriteString(fmt.Sprintf("%d. %s\n", i+1, formatTitle(color, place.Name, place.Address)))
+			writeLine(&out, color, "Detour", routeDetourLine(numbers, place))
+		}
+	}
+
+	if len(response.Steps) > 0 {
+		out.WriteString("\n")
+		out.WriteString(color.Bold(fmt.Sprintf("Steps (%d)", len(response.Steps))))
+		out.WriteString("\n")
+		for i, step := range response.Steps {
+			out.WriteString(fmt.Sprintf("  %d. %s\n", i+1, directionsStepLine(step)))
+		}
+	}
+
+	if len(response.Routes) > 0 {
+		out.WriteString("\n")
+		out.WriteString(renderLabeledRoutes(color, numbers, response.Routes))
+	}
+
+	if response.RouteToken != "" {
+		out.WriteString("\n")
+		writeLine(&out, color, "Route token", response.RouteToken)
+	}
+
+	return out.String()
+}
+
+func renderLabeledRoutes(color Color, numbers numberFormat, routes []goplaces.LabeledRoute) string {
+	var out bytes.Buffer
+	out.WriteString(color.Bold(fmt.Sprintf("Routes (%d)", len(routes))))
+	out.WriteString("\n")
+	table := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "Label\tDistance\tDuration")
+	for _, route := range routes {
+		label := strings.Join(route.Labels, ", ")
+		if label == "" {
+			label = "-"
+		}
+		_, _ = fmt.Fprintf(table, "%s\t%s km\t%d min\n",
+			label, numbers.float(float64(route.DistanceMeters)/1000, 1), int(math.Round(float64(route.DurationSeconds)/60)))
+	}
+	_ = table.Flush()
 	return out.String()
 }
 
+func routeDetourLine(numbers numberFormat, place goplaces.RoutePlace) string {
+	parts := []string{numbers.float(place.DistanceFromRouteM, 0) + " m off route"}
+	if place.DetourSeconds != nil {
+		parts = append(parts, fmt.Sprintf("+%d min", (*place.DetourSeconds+59)/60))
+	}
+	return strings.Join(parts, " · ")
+}
+
 func renderDirections(color Color, response goplaces.DirectionsResponse, includeSteps bool) string {
 	var out bytes.Buffer
 	mode := strings.TrimSpace(response.Mode)
@@ -167,8 +237,19 @@ func renderDirections(color Color, response goplaces.DirectionsResponse, include
 	writeLine(&out, color, "From", response.StartAddress)
 	writeLine(&out, color, "To", response.EndAddress)
 	writeLine(&out, color, "Summary", response.Summary)
-	writeLine(&out, color, "Distance", response.DistanceText)
+	writeLine(&out, color, "Distance", distanceWithUnits(response))
 	writeLine(&out, color, "Duration", response.DurationText)
+	writeLine(&out, color, "Overview", directionsOverview(response))
+	if response.Fare != nil {
+		writeLine(&out, color, "Fare", response.Fare.Text)
+	}
+	if len(response.WaypointOrder) > 0 {
+		stops := make([]string, len(response.WaypointOrder))
+		for i, index := range response.WaypointOrder {
+			stops[i] = strconv.Itoa(index + 1)
+		}
+		writeLine(&out, color, "Stop order", strings.Join(stops, " → "))
+	}
 	if len(response.Warnings) > 0 {
 		out.WriteString(color.Dim("Warnings:"))
 		out.WriteString("\n")
@@ -201,6 +282,140 @@ func renderDirections(color Color, response goplaces.DirectionsResponse, include
 	return out.String()
 }
 
+// directionsOverview renders e.g. "3 steps · 2 turns · 1.2 km · 14 mins",
+// counting steps with a maneuver as turns. Routes without steps get none.
+func directionsOverview(response goplaces.DirectionsResponse) string {
+	if len(response.Steps) == 0 {
+		return ""
+	}
+	turns := 0
+	for _, step := range response.Steps {
+		if strings.TrimSpace(step.Maneuver) != "" {
+			turns++
+		}
+	}
+	distance := response.DistanceText
+	if strings.TrimSpace(distance) == "" {
+		distance = goplaces.FormatDistance(response.DistanceMeters, response.Units)
+	}
+	duration := response.DurationText
+	if strings.TrimSpace(duration) == "" {
+		duration = goplaces.FormatDuration(response.DurationSeconds)
+	}
+	return strings.Join([]string{
+		pluralize(len(response.Steps), "step"), pluralize(turns, "turn"), distance, duration,
+	}, " · ")
+}
+
+// distanceWithUnits labels the distance with its unit system, e.g.
+// "0.6 mi (imperial)", so compared routes are visibly in the same units.
+func distanceWithUnits(response goplaces.DirectionsResponse) string {
+	if strings.TrimSpace(response.DistanceText) == "" || response.Units == "" {
+		return response.DistanceText
+	}
+	return fmt.Sprintf("%s (%s)", response.DistanceText, response.Units)
+}
+
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+func renderValidate(color Color, lines []validatedLine) string {
+	var out bytes.Buffer
+	ok := 0
+	for _, line := range lines {
+		if line.Status == goplaces.LocationOK {
+			ok++
+		}
+	}
+	out.WriteString(color.Bold(fmt.Sprintf("Validated %d/%d", ok, len(lines))))
+	out.WriteString("\n")
+	table := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "Line\tStatus\tInput\tResult")
+	for _, line := range lines {
+		result := strings.Join(uniqueStrings([]string{line.Reason, line.Address}), " · ")
+		if result == "" {
+			result = "-"
+		}
+		_, _ = fmt.Fprintf(table, "%d\t%s\t%s\t%s\n", line.Line, line.Status, line.Input, result)
+	}
+	_ = table.Flush()
+	return out.String()
+}
+
+// renderRouteSummaries prints a fastest-first comparison table.
+func renderRouteSummaries(color Color, summaries []goplaces.RouteSummary) string {
+	var out bytes.Buffer
+	out.WriteString(color.Bold(fmt.Sprintf("Routes (%d, fastest first)", len(summaries))))
+	out.WriteString("\n")
+	table := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "#\tRoute\tDistance\tDuration\tIn traffic")
+	for _, summary := range summaries {
+		traffic := summary.DurationInTrafficText
+		if traffic == "" {
+			traffic = "-"
+		}
+		_, _ = fmt.Fprintf(table, "%d\t%s\t%s\t%s\t%s\n",
+			summary.Index+1, summary.Label, summary.DistanceText, summary.DurationText, traffic)
+	}
+	_ = table.Flush()
+	return out.String()
+}
+
+func renderDirectionsDiff(color Color, numbers numberFormat, primary, compare goplaces.DirectionsResponse, diff goplaces.DirectionsDiff) string {
+	var out bytes.Buffer
+	out.WriteString(color.Bold(fmt.Sprintf("Diff (%s → %s)", primary.Mode, compare.Mode)))
+	out.WriteString("\n")
+	writeLine(&out, color, "Distance", signedKm(numbers, diff.DistanceMeters))
+	writeLine(&out, color, "Duration", signedMinutes(diff.DurationSeconds))
+	if len(diff.OnlyInA) == 0 && len(diff.OnlyInB) == 0 {
+		out.WriteString(color.Dim("Steps: identical"))
+		out.WriteString("\n")
+		return out.String()
+	}
+	out.WriteString(color.Dim("Steps:"))
+	out.WriteString("\n")
+	for _, step := range diff.OnlyInA {
+		out.WriteString(fmt.Sprintf("  - %d. %s\n", step.Index+1, directionsStepLine(step.Step)))
+	}
+	for _, step := range diff.OnlyInB {
+		out.WriteString(fmt.Sprintf("  + %d. %s\n", step.Index+1, directionsStepLine(step.Step)))
+	}
+	return out.String()
+}
+
+func renderRoundTrip(color Color, numbers numberFormat, trip directionsRoundTrip, includeSteps bool) string {
+	var out bytes.Buffer
+	out.WriteString(renderDirections(color, trip.Outbound, includeSteps))
+	out.WriteString("\n\n")
+	out.WriteString(renderDirections(color, trip.Return, includeSteps))
+	out.WriteString("\n\n")
+	out.WriteString(color.Bold(fmt.Sprintf("Round trip (%s + %s)", trip.Outbound.Mode, trip.Return.Mode)))
+	out.WriteString("\n")
+	distance := numbers.float(float64(trip.TotalDistanceMeters)/1000, 1) + " km"
+	if trip.Outbound.Units == "imperial" {
+		distance = numbers.float(float64(trip.TotalDistanceMeters)/1609.344, 1) + " mi"
+	}
+	writeLine(&out, color, "Distance", distance)
+	writeLine(&out, color, "Duration", fmt.Sprintf("%d min", int(math.Round(float64(trip.TotalDurationSeconds)/60))))
+	return out.String()
+}
+
+func signedKm(numbers numberFormat, meters int) string {
+	km := numbers.float(float64(meters)/1000, 1)
+	if meters >= 0 {
+		km = "+" + km
+	}
+	return km + " km"
+}
+
+func signedMinutes(seconds int) string {
+	return fmt.Sprintf("%+d min", int(math.Round(float64(seconds)/60)))
+}
+
 func formatTitle(color Color, name string, address string) string {
 	display := strings.TrimSpace(name)
 	if display == "" {
@@ -231,12 +446,26 @@ func autocompleteSubtitle(suggestion goplaces.AutocompleteSuggestion) string {
 	return suggestion.Text
 }
 
-func writePlaceSummary(out *bytes.Buffer, color Color, place goplaces.PlaceSummary) {
+func writePlaceSummary(out *bytes.Buffer, color Color, numbers numberFormat, place goplaces.PlaceSummary) {
 	writeLine(out, color, "ID", place.PlaceID)
 	writeLocation(out, color, place.Location)
-	writeRating(out, color, place.Rating, place.PriceLevel)
+	writeRating(out, color, numbers, place.Rating, place.PriceLevel)
+	writeLine(out, color, "Price", formatPriceRange(place.PriceRange))
 	writeTypes(out, color, place.Types)
 	writeOpenNow(out, color, place.OpenNow)
+	writeEVChargeOptions(out, color, place.EVChargeOptions)
+}
+
+func writeEVChargeOptions(out *bytes.Buffer, color Color, options *goplaces.EVChargeOptions) {
+	if options == nil {
+		return
+	}
+	available, known := options.AvailableConnectors()
+	if !known {
+		writeLine(out, color, "EV", fmt.Sprintf("%d connectors (availability unknown)", options.ConnectorCount))
+		return
+	}
+	writeLine(out, color, "EV", fmt.Sprintf("%d/%d available", available, options.ConnectorCount))
 }
 
 func writeAutocompleteSuggestion(out *bytes.Buffer, color Color, suggestion goplaces.AutocompleteSuggestion) {
@@ -249,16 +478,20 @@ func writeAutocompleteSuggestion(out *bytes.Buffer, color Color, suggestion gopl
 	}
 }
 
-func writePlaceDetails(out *bytes.Buffer, color Color, place goplaces.PlaceDetails) {
+func writePlaceDetails(out *bytes.Buffer, color Color, numbers numberFormat, place goplaces.PlaceDetails) {
 	writeLine(out, color, "ID", place.PlaceID)
+	if place.EditorialSummary != nil {
+		writeLine(out, color, "Summary", place.EditorialSummary.Text)
+	}
 	writeLocation(out, color, place.Location)
-	writeRating(out, color, place.Rating, place.PriceLevel)
+	writeRating(out, color, numbers, place.Rating, place.PriceLevel)
+	writeLine(out, color, "Price", formatPriceRange(place.PriceRange))
 	writeTypes(out, color, place.Types)
 	writeOpenNow(out, color, place.OpenNow)
 	writeLine(out, color, "Phone", place.Phone)
 	writeLine(out, color, "Website", place.Website)
 	writePhotos(out, color, place.Photos)
-	writeReviews(out, color, place.Reviews)
+	writeReviews(out, color, numbers, place.Reviews)
 	if len(place.Hours) > 0 {
 		out.WriteString(color.Dim("Hours:"))
 		out.WriteString("\n")
@@ -307,7 +540,7 @@ func writePhotos(out *bytes.Buffer, color Color, photos []goplaces.Photo) {
 	}
 }
 
-func writeReviews(out *bytes.Buffer, color Color, reviews []goplaces.Review) {
+func writeReviews(out *bytes.Buffer, color Color, numbers numberFormat, reviews []goplaces.Review) {
 	if len(reviews) == 0 {
 		return
 	}
@@ -324,7 +557,7 @@ func writeReviews(out *bytes.Buffer, color Color, reviews []goplaces.Review) {
 
 	for i := 0; i < limit; i++ {
 		review := reviews[i]
-		line := reviewLine(review)
+		line := reviewLine(numbers, review)
 		if line == "" {
 			continue
 		}
@@ -346,13 +579,13 @@ func writeLocation(out *bytes.Buffer, color Color, loc *goplaces.LatLng) {
 	writeLine(out, color, "Location", fmt.Sprintf("%.6f, %.6f", loc.Lat, loc.Lng))
 }
 
-func writeRating(out *bytes.Buffer, color Color, rating *float64, priceLevel *int) {
+func writeRating(out *bytes.Buffer, color Color, numbers numberFormat, rating *float64, priceLevel *int) {
 	if rating == nil && priceLevel == nil {
 		return
 	}
 	parts := make([]string, 0, 2)
 	if rating != nil {
-		parts = append(parts, fmt.Sprintf("%.1f", *rating))
+		parts = append(parts, numbers.float(*rating, 1))
 	}
 	if priceLevel != nil {
 		parts = append(parts, fmt.Sprintf("$%d", *priceLevel))
@@ -360,6 +593,29 @@ func writeRating(out *bytes.Buffer, color Color, rating *float64, priceLevel *in
 	writeLine(out, color, "Rating", strings.Join(parts, " · "))
 }
 
+// formatPriceRange renders "USD 20–30", or "USD 100+" when open-ended.
+func formatPriceRange(priceRange *goplaces.PriceRange) string {
+	if priceRange == nil {
+		return ""
+	}
+	amount := func(value *float64) string {
+		if value == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*value, 'f', -1, 64)
+	}
+	band := amount(priceRange.StartAmount)
+	switch {
+	case priceRange.EndAmount == nil:
+		band += "+"
+	case priceRange.StartAmount == nil:
+		band = "up to " + amount(priceRange.EndAmount)
+	default:
+		band += "–" + amount(priceRange.EndAmount)
+	}
+	return strings.TrimSpace(priceRange.Currency + " " + band)
+}
+
 func writeTypes(out *bytes.Buffer, color Color, types []string) {
 	if len(types) == 0 {
 		return
@@ -389,10 +645,10 @@ func writeLine(out *bytes.Buffer, color Color, label string, value string) {
 	out.WriteString("\n")
 }
 
-func reviewLine(review goplaces.Review) string {
+func reviewLine(numbers numberFormat, review goplaces.Review) string {
 	parts := make([]string, 0, 3)
 	if review.Rating != nil {
-		parts = append(parts, fmt.Sprintf("%.1f stars", *review.Rating))
+		parts = append(parts, numbers.float(*review.Rating, 1)+" stars")
 	}
 	if review.Author != nil && strings.TrimSpace(review.Author.DisplayName) != "" {
 		parts = append(parts, "by "+review.Author.DisplayName)
@@ -450,6 +706,9 @@ func directionsStepLine(step goplaces.DirectionsStep) string {
 		instruction = "(no instruction)"
 	}
 	parts := []string{instruction}
+	if transit := transitStepSummary(step.TransitDetails); transit != "" {
+		parts = append(parts, transit)
+	}
 	if strings.TrimSpace(step.DistanceText) != "" {
 		parts = append(parts, step.DistanceText)
 	}
@@ -459,6 +718,35 @@ func directionsStepLine(step goplaces.DirectionsStep) string {
 	return strings.Join(parts, " · ")
 }
 
+// transitStepSummary renders e.g. "U2 → Pankow, 5 stops, 9:05am from Alexanderplatz".
+func transitStepSummary(details *goplaces.TransitDetails) string {
+	if details == nil {
+		return ""
+	}
+	line := details.LineShortName
+	if line == "" {
+		line = details.LineName
+	}
+	if details.Headsign != "" {
+		line = strings.TrimSpace(line + " → " + details.Headsign)
+	}
+	parts := []string{}
+	if line != "" {
+		parts = append(parts, line)
+	}
+	if details.NumStops > 0 {
+		parts = append(parts, fmt.Sprintf("%d stops", details.NumStops))
+	}
+	if details.DepartureStop != "" {
+		departure := "from " + details.DepartureStop
+		if details.DepartureTimeText != "" {
+			departure = details.DepartureTimeText + " " + departure
+		}
+		parts = append(parts, departure)
+	}
+	return strings.Join(parts, ", ")
+}
+
 func uniqueStrings(values []string) []string {
 	seen := make(map[string]struct{}, len(values))
 	result := make([]string, 0, len(values))
@@ -28,7 +28,7 @@ func TestRenderSearch(t *testing.T) {
 		NextPageToken: "next",
 	}
 
-	output := renderSearch(NewColor(false), response)
+	output := renderSearch(NewColor(false), numberFormat{}, response)
 	if !strings.Contains(output, "Cafe") {
 		t.Fatalf("missing name")
 	}
@@ -44,7 +44,7 @@ func TestRenderSearch(t *testing.T) {
 }
 
 func TestRenderSearchEmpty(t *testing.T) {
-	output := renderSearch(NewColor(false), goplaces.SearchResponse{})
+	output := renderSearch(NewColor(false), numberFormat{}, goplaces.SearchResponse{})
 	if !strings.Contains(output, "No results") {
 		t.Fatalf("unexpected output: %s", output)
 	}
@@ -91,7 +91,7 @@ func TestRenderNearby(t *testing.T) {
 		},
 		NextPageToken: "next",
 	}
-	output := renderNearby(NewColor(false), response)
+	output := renderNearby(NewColor(false), numberFormat{}, response, false)
 	if !strings.Contains(output, "Nearby") {
 		t.Fatalf("missing nearby header")
 	}
@@ -103,6 +103,50 @@ func TestRenderNearby(t *testing.T) {
 	}
 }
 
+func TestRenderNearbyTypeDisplay(t *testing.T) {
+	response := goplaces.NearbySearchResponse{
+		Results: []goplaces.PlaceSummary{
+			{PlaceID: "place-1", Name: "Cafe", PrimaryType: "cafe", PrimaryTypeName: "Coffee Shop"},
+			{PlaceID: "place-2", Name: "Loaf", PrimaryType: "bakery"},
+			{PlaceID: "place-3", Name: "Mystery"},
+		},
+	}
+	output := renderNearby(NewColor(false), numberFormat{}, response, true)
+	for _, want := range []string{"1. Cafe [Coffee Shop]", "2. Loaf [bakery]", "3. Mystery\n"} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("missing %q in output: %s", want, output)
+		}
+	}
+	if strings.Contains(renderNearby(NewColor(false), numberFormat{}, response, false), "[Coffee Shop]") {
+		t.Fatalf("type display should be opt-in")
+	}
+}
+
+func TestWriteEVChargeOptionsUnknown(t *testing.T) {
+	var out bytes.Buffer
+	writeEVChargeOptions(&out, NewColor(false), &goplaces.EVChargeOptions{
+		ConnectorCount: 3,
+		Connectors:     []goplaces.EVConnector{{Count: 3}},
+	})
+	if !strings.Contains(out.String(), "EV: 3 connectors (availability unknown)") {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+}
+
+func TestRenderRouteSummaries(t *testing.T) {
+	output := renderRouteSummaries(NewColor(false), []goplaces.RouteSummary{
+		{Index: 1, Label: "DRIVING", DistanceText: "5 km", DurationText: "8 mins", DurationInTrafficText: "12 mins"},
+		{Index: 0, Label: "WALKING", DistanceText: "4 km", DurationText: "50 mins"},
+	})
+	if !strings.Contains(output, "Routes (2, fastest first)") {
+		t.Fatalf("missing header: %s", output)
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[2], "2  DRIVING") || !strings.HasSuffix(lines[3], "-") {
+		t.Fatalf("unexpected table: %q", lines)
+	}
+}
+
 func TestRenderRoute(t *testing.T) {
 	response := goplaces.RouteResponse{
 		Waypoints: []goplaces.RouteWaypoint{
@@ -112,7 +156,7 @@ func TestRenderRoute(t *testing.T) {
 			},
 		},
 	}
-	output := renderRoute(NewColor(false), response)
+	output := renderRoute(NewColor(false), numberFormat{}, response)
 	if !strings.Contains(output, "Route waypoints") {
 		t.Fatalf("missing route header")
 	}
@@ -124,8 +168,66 @@ func TestRenderRoute(t *testing.T) {
 	}
 }
 
+func TestRenderRouteStopsByDetour(t *testing.T) {
+	detour := 150
+	response := goplaces.RouteResponse{
+		Waypoints: []goplaces.RouteWaypoint{{Location: goplaces.LatLng{Lat: 1, Lng: 2}}},
+		Places: []goplaces.RoutePlace{
+			{PlaceSummary: goplaces.PlaceSummary{PlaceID: "place-1", Name: "Cafe"}, DistanceFromRouteM: 120, DetourSeconds: &detour},
+		},
+	}
+	output := renderRoute(NewColor(false), numberFormat{}, response)
+	if !strings.Contains(output, "Stops by detour (1)") {
+		t.Fatalf("missing stops header: %s", output)
+	}
+	if !strings.Contains(output, "120 m off route · +3 min") {
+		t.Fatalf("missing detour line: %s", output)
+	}
+}
+
+func TestRenderRouteReferenceRoutes(t *testing.T) {
+	response := goplaces.RouteResponse{
+		Waypoints: []goplaces.RouteWaypoint{{Location: goplaces.LatLng{Lat: 1, Lng: 2}}},
+		Routes: []goplaces.LabeledRoute{
+			{Labels: []string{"DEFAULT_ROUTE"}, DistanceMeters: 12000, DurationSeconds: 900},
+			{Labels: []string{"FUEL_EFFICIENT"}, DistanceMeters: 11500, DurationSeconds: 1020},
+		},
+	}
+	output := renderRoute(NewColor(false), numberFormat{}, response)
+	if !strings.Contains(output, "Routes (2)") {
+		t.Fatalf("missing routes header: %s", output)
+	}
+	if !strings.Contains(output, "FUEL_EFFICIENT  11.5 km   17 min") {
+		t.Fatalf("missing reference route row: %s", output)
+	}
+}
+
+func TestRenderRouteSteps(t *testing.T) {
+	response := goplaces.RouteResponse{
+		Waypoints: []goplaces.RouteWaypoint{{Location: goplaces.LatLng{Lat: 1, Lng: 2}}},
+		Steps: []goplaces.DirectionsStep{
+			{Instruction: "Turn left onto Pine St", DistanceText: "0.1 km", DurationText: "1 min"},
+		},
+	}
+	output := renderRoute(NewColor(false), numberFormat{}, response)
+	if !strings.Contains(output, "Steps (1)") || !strings.Contains(output, "1. Turn left onto Pine St · 0.1 km · 1 min") {
+		t.Fatalf("missing route steps: %s", output)
+	}
+}
+
+func TestRenderRouteToken(t *testing.T) {
+	response := goplaces.RouteResponse{
+		Waypoints:  []goplaces.RouteWaypoint{{Location: goplaces.LatLng{Lat: 1, Lng: 2}}},
+		RouteToken: "CqMBCjoKMgoU",
+	}
+	output := renderRoute(NewColor(false), numberFormat{}, response)
+	if !strings.Contains(output, "Route token: CqMBCjoKMgoU") {
+		t.Fatalf("missing route token: %s", output)
+	}
+}
+
 func TestRenderRouteEmpty(t *testing.T) {
-	output := renderRoute(NewColor(false), goplaces.RouteResponse{})
+	output := renderRoute(NewColor(false), numberFormat{}, goplaces.RouteResponse{})
 	if !strings.Contains(output, "No results") {
 		t.Fatalf("unexpected output: %s", output)
 	}
@@ -141,6 +243,8 @@ func TestRenderDirections(t *testing.T) {
 		Steps: []goplaces.DirectionsStep{
 			{Instruction: "Head north", DistanceText: "0.2 km", DurationText: "2 mins"},
 		},
+		WaypointOrder: []int{2, 0, 1},
+		Fare:          &goplaces.Fare{Currency: "EUR", Text: "€3.50", Value: 3.5},
 	}
 	output := renderDirections(NewColor(false), response, true)
 	if !strings.Contains(output, "Directions") {
@@ -152,6 +256,41 @@ func TestRenderDirections(t *testing.T) {
 	if !strings.Contains(output, "Distance") {
 		t.Fatalf("missing distance")
 	}
+	if !strings.Contains(output, "Stop order: 3 → 1 → 2") {
+		t.Fatalf("missing stop order: %s", output)
+	}
+	if !strings.Contains(output, "Fare: €3.50") {
+		t.Fatalf("missing fare: %s", output)
+	}
+}
+
+func TestRenderDirectionsOverview(t *testing.T) {
+	response := goplaces.DirectionsResponse{
+		Mode:            "DRIVING",
+		DistanceMeters:  1234,
+		DurationSeconds: 840,
+		Steps: []goplaces.DirectionsStep{
+			{Instruction: "Head north"},
+			{Instruction: "Turn left", Maneuver: "turn-left"},
+			{Instruction: "Turn right", Maneuver: "turn-right"},
+		},
+	}
+	output := renderDirections(NewColor(false), response, false)
+	if !strings.Contains(output, "Overview: 3 steps · 2 turns · 1.2 km · 14 mins\n") {
+		t.Fatalf("missing overview: %s", output)
+	}
+	if strings.Contains(output, "Turn left") {
+		t.Fatalf("steps printed without --steps: %s", output)
+	}
+
+	response.DistanceText, response.DurationText = "0.8 mi", "15 mins"
+	response.Steps = response.Steps[1:2]
+	if output := renderDirections(NewColor(false), response, false); !strings.Contains(output, "Overview: 1 step · 1 turn · 0.8 mi · 15 mins\n") {
+		t.Fatalf("overview should prefer Google's texts: %s", output)
+	}
+	if output := renderDirections(NewColor(false), goplaces.DirectionsResponse{}, false); strings.Contains(output, "Overview") {
+		t.Fatalf("unexpected overview without steps: %s", output)
+	}
 }
 
 func TestFormatTitleFallback(t *testing.T) {
@@ -187,6 +326,10 @@ func TestRenderDetailsAndResolve(t *testing.T) {
 		Website:    "https://example.com",
 		Hours:      []string{"Mon: 9-5"},
 		OpenNow:    &open,
+		EditorialSummary: &goplaces.LocalizedText{
+			Text:         "Leafy park with a pond.",
+			LanguageCode: "en",
+		},
 		Photos: []goplaces.Photo{
 			{Name: "places/place-1/photos/photo-1", WidthPx: 1200, HeightPx: 800},
 		},
@@ -199,13 +342,16 @@ func TestRenderDetailsAndResolve(t *testing.T) {
 			},
 		},
 	}
-	output := renderDetails(NewColor(false), details)
+	output := renderDetails(NewColor(false), numberFormat{}, details)
 	if !strings.Contains(output, "Park") || !strings.Contains(output, "Hours:") {
 		t.Fatalf("unexpected details output: %s", output)
 	}
 	if !strings.Contains(output, "Photos:") {
 		t.Fatalf("missing photos output: %s", output)
 	}
+	if !strings.Contains(output, "Summary: Leafy park with a pond.") {
+		t.Fatalf("missing editorial summary: %s", output)
+	}
 	if !strings.Contains(output, "Reviews:") || !strings.Contains(output, "Alice") {
 		t.Fatalf("missing reviews output: %s", output)
 	}
@@ -219,6 +365,27 @@ func TestRenderDetailsAndResolve(t *testing.T) {
 	}
 }
 
+func TestRenderLocalizedNumbers(t *testing.T) {
+	numbers, err := parseNumberLocale("de")
+	if err != nil {
+		t.Fatalf("parse locale: %v", err)
+	}
+	output := renderDetails(NewColor(false), numbers, goplaces.PlaceDetails{
+		Name:    "Park",
+		Rating:  floatPtr(4.2),
+		Reviews: []goplaces.Review{{Rating: floatPtr(4.5)}},
+	})
+	if !strings.Contains(output, "Rating: 4,2") || !strings.Contains(output, "4,5 stars") {
+		t.Fatalf("expected comma decimals: %s", output)
+	}
+	if got := signedKm(numbers, 1260); got != "+1,3 km" {
+		t.Fatalf("unexpected signed km: %s", got)
+	}
+	if got := signedKm(numbers, -400); got != "-0,4 km" {
+		t.Fatalf("unexpected signed km: %s", got)
+	}
+}
+
 func TestRenderPhoto(t *testing.T) {
 	output := renderPhoto(NewColor(false), goplaces.PhotoMediaResponse{
 		Name:     "places/place-1/photos/photo-1",
@@ -285,3 +452,39 @@ func TestColorWrap(t *testing.T) {
 func floatPtr(v float64) *float64 {
 	return &v
 }
+
+func TestFormatPriceRange(t *testing.T) {
+	start, end := 20.0, 30.5
+	cases := []struct {
+		priceRange *goplaces.PriceRange
+		want       string
+	}{
+		{nil, ""},
+		{&goplaces.PriceRange{Currency: "USD", StartAmount: &start, EndAmount: &end}, "USD 20–30.5"},
+		{&goplaces.PriceRange{Currency: "EUR", StartAmount: &start}, "EUR 20+"},
+		{&goplaces.PriceRange{Currency: "GBP", EndAmount: &end}, "GBP up to 30.5"},
+	}
+	for _, tc := range cases {
+		if got := formatPriceRange(tc.priceRange); got != tc.want {
+			t.Fatalf("expected %q, got %q", tc.want, got)
+		}
+	}
+}
+
+func TestDirectionsStepLineTransit(t *testing.T) {
+	line := directionsStepLine(goplaces.DirectionsStep{
+		Instruction:  "Subway towards Pankow",
+		DurationText: "7 mins",
+		TransitDetails: &goplaces.TransitDetails{
+			LineShortName:     "U2",
+			Headsign:          "Pankow",
+			NumStops:          5,
+			DepartureStop:     "Alexanderplatz",
+			DepartureTimeText: "9:05 AM",
+		},
+	})
+	want := "Subway towards Pankow · U2 → Pankow, 5 stops, 9:05 AM from Alexanderplatz · 7 mins"
+	if line != want {
+		t.Fatalf("unexpected line:\n got %q\nwant %q", line, want)
+	}
+}
@@ -15,16 +15,25 @@ type Root struct {
 	Details      DetailsCmd      `cmd:"" help:"Fetch place details by place ID."`
 	Photo        PhotoCmd        `cmd:"" help:"Fetch a photo URL by photo name."`
 	Resolve      ResolveCmd      `cmd:"" help:"Resolve a location string to candidate places."`
+	Validate     ValidateCmd     `cmd:"" help:"Check a file of addresses or coordinates with the Geocoding API."`
 }
 
 // GlobalOptions are flags shared by all commands.
 type GlobalOptions struct {
-	APIKey            string        `help:"Google Places API key." env:"GOOGLE_PLACES_API_KEY"`
+	APIKey            string        `help:"Google Places API key." env:"GOOGLE_PLACES_API_KEY,GOOGLE_MAPS_API_KEY"`
 	BaseURL           string        `help:"Places API base URL." env:"GOOGLE_PLACES_BASE_URL" default:"https://places.googleapis.com/v1"`
 	RoutesBaseURL     string        `help:"Routes API base URL." env:"GOOGLE_ROUTES_BASE_URL" default:"https://routes.googleapis.com"`
 	DirectionsBaseURL string        `help:"Directions API base URL." env:"GOOGLE_DIRECTIONS_BASE_URL" default:"https://maps.googleapis.com/maps/api/directions/json"`
+	GeocodeBaseURL    string        `help:"Geocoding API base URL." env:"GOOGLE_GEOCODE_BASE_URL" default:"https://maps.googleapis.com/maps/api/geocode/json"`
+	AutoRegion        bool          `help:"Derive the directions region from origin coordinates (Geocoding API)."`
+	AutoUnits         bool          `help:"Without --units, pick miles or km from the destination country (Geocoding API)."`
+	StrictWarnings    bool          `help:"Fail when a route carries warnings."`
 	Timeout           time.Duration `help:"HTTP timeout." default:"10s"`
-	JSON              bool          `help:"Output JSON."`
+	MaxRetries        int           `help:"Retry 429/5xx and OVER_QUERY_LIMIT responses up to N times with exponential backoff." env:"GOPLACES_MAX_RETRIES" default:"0"`
+	JSON              bool          `help:"Output JSON (indented)."`
+	JSONCompact       bool          `help:"Output single-line JSON for piping (implies --json)." name:"json-compact"`
+	JSONStyle         string        `help:"JSON key style: snake or camel." name:"json-style" enum:"snake,camel" default:"snake"`
+	Locale            string        `help:"Number locale for text and CSV output: C (dot decimals) or a tag like de-DE (comma decimals, ; in CSV)." env:"GOPLACES_LOCALE" default:"C"`
 	NoColor           bool          `help:"Disable color output."`
 	Verbose           bool          `help:"Verbose logging."`
 	Version           VersionFlag   `name:"version" help:"Print version and exit."`
@@ -32,19 +41,24 @@ type GlobalOptions struct {
 
 // SearchCmd runs text search queries.
 type SearchCmd struct {
-	Query      string   `arg:"" name:"query" help:"Search text."`
-	Limit      int      `help:"Max results (1-20)." default:"10"`
-	PageToken  string   `help:"Page token for pagination."`
-	Language   string   `help:"BCP-47 language code (e.g. en, en-US)."`
-	Region     string   `help:"CLDR region code (e.g. US, DE)."`
-	Keyword    string   `help:"Keyword to append to the query."`
-	Type       []string `help:"Place type filter (includedType). Repeatable."`
-	OpenNow    *bool    `help:"Return only currently open places."`
-	MinRating  *float64 `help:"Minimum rating (0-5)."`
-	PriceLevel []int    `help:"Price levels 0-4. Repeatable."`
-	Lat        *float64 `help:"Latitude for location bias."`
-	Lng        *float64 `help:"Longitude for location bias."`
-	RadiusM    *float64 `help:"Radius in meters for location bias."`
+	Query       string   `arg:"" name:"query" help:"Search text."`
+	Limit       int      `help:"Max results (1-20)." default:"10"`
+	PageToken   string   `help:"Page token for pagination."`
+	Language    string   `help:"BCP-47 language code (e.g. en, en-US)."`
+	Region      string   `help:"CLDR region code (e.g. US, DE)."`
+	Keyword     string   `help:"Keyword to append to the query."`
+	Type        []string `help:"Place type filter (includedType). Repeatable."`
+	OpenNow     *bool    `help:"Return only currently open places."`
+	MinRating   *float64 `help:"Minimum rating (0-5)."`
+	PriceLevel  []int    `help:"Price levels 0-4. Repeatable."`
+	Lat         *float64 `help:"Latitude for location bias."`
+	Lng         *float64 `help:"Longitude for location bias."`
+	RadiusM     *float64 `help:"Radius in meters for location bias."`
+	EVAvailable bool     `help:"Only show EV chargers with a free connector (live data)." name:"ev-available"`
+	Head        int      `help:"Show only the first N results after client-side filtering (0 shows all)."`
+	Format      string   `help:"Output format: text, geojson (FeatureCollection of points), or ndjson (one place per line, all pages)." enum:"text,geojson,ndjson" default:"text"`
+	CountOnly   bool     `help:"Print only the number of results, following every page." name:"count-only"`
+	FailOnEmpty bool     `help:"Exit 1 when there are no results." name:"fail-on-empty"`
 }
 
 // AutocompleteCmd runs autocomplete queries.
@@ -52,11 +66,14 @@ type AutocompleteCmd struct {
 	Input        string   `arg:"" name:"input" help:"Autocomplete input text."`
 	Limit        int      `help:"Max suggestions (1-20)." default:"5"`
 	SessionToken string   `help:"Session token for billing consistency."`
+	SaveSession  bool     `help:"Reuse or start a session token saved per input in the user cache dir for 'details --session'." name:"save-session"`
 	Language     string   `help:"BCP-47 language code (e.g. en, en-US)."`
 	Region       string   `help:"CLDR region code (e.g. US, DE)."`
 	Lat          *float64 `help:"Latitude for location bias."`
 	Lng          *float64 `help:"Longitude for location bias."`
 	RadiusM      *float64 `help:"Radius in meters for location bias."`
+	Head         int      `help:"Show only the first N results after client-side filtering (0 shows all)."`
+	Format       string   `help:"Output format: text or ndjson (one suggestion per line)." enum:"text,ndjson" default:"text"`
 }
 
 // NearbyCmd runs nearby searches.
@@ -69,6 +86,12 @@ type NearbyCmd struct {
 	Lat         *float64 `help:"Latitude for location restriction."`
 	Lng         *float64 `help:"Longitude for location restriction."`
 	RadiusM     *float64 `help:"Radius in meters for location restriction."`
+	TypeDisplay bool     `help:"Show each place's localized category next to its name." name:"type-display"`
+	EVAvailable bool     `help:"Only show EV chargers with a free connector (live data)." name:"ev-available"`
+	Head        int      `help:"Show only the first N results after client-side filtering (0 shows all)."`
+	Format      string   `help:"Output format: text, geojson (FeatureCollection of points), or ndjson (one place per line, all pages)." enum:"text,geojson,ndjson" default:"text"`
+	CountOnly   bool     `help:"Print only the number of results, following every page." name:"count-only"`
+	FailOnEmpty bool     `help:"Exit 1 when there are no results." name:"fail-on-empty"`
 }
 
 // DetailsCmd fetches place details.
@@ -78,6 +101,8 @@ type DetailsCmd struct {
 	Region   string `help:"CLDR region code (e.g. US, DE)."`
 	Reviews  bool   `help:"Include reviews in the response."`
 	Photos   bool   `help:"Include photos in the response."`
+	Summary  bool   `help:"Include Google's editorial summary in the response."`
+	Session  bool   `help:"Use and end the session saved by 'autocomplete --save-session'."`
 }
 
 // PhotoCmd fetches a photo URL.
@@ -85,6 +110,7 @@ type PhotoCmd struct {
 	Name        string `arg:"" name:"photo_name" help:"Photo resource name (places/.../photos/...)."`
 	MaxWidthPx  int    `help:"Max width in pixels." name:"max-width"`
 	MaxHeightPx int    `help:"Max height in pixels." name:"max-height"`
+	Output      string `help:"Download the image to this file instead of printing its URL." short:"o" type:"path"`
 }
 
 // ResolveCmd resolves a location string into candidates.
@@ -93,4 +119,6 @@ type ResolveCmd struct {
 	Limit        int    `help:"Max results (1-10)." default:"5"`
 	Language     string `help:"BCP-47 language code (e.g. en, en-US)."`
 	Region       string `help:"CLDR region code (e.g. US, DE)."`
+	Head         int    `help:"Show only the first N results after client-side filtering (0 shows all)."`
+	Format       string `help:"Output format: text or ndjson (one place per line)." enum:"text,ndjson" default:"text"`
 }
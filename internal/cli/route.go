@@ -9,29 +9,47 @@ import (
 
 // RouteCmd searches along a route between two locations.
 type RouteCmd struct {
-	Query        string  `arg:"" name:"query" help:"Search text."`
-	From         string  `help:"Origin location (address or place name)."`
-	To           string  `help:"Destination location (address or place name)."`
-	Mode         string  `help:"Travel mode: DRIVE, WALK, BICYCLE, TWO_WHEELER, TRANSIT." default:"DRIVE"`
-	RadiusM      float64 `help:"Search radius in meters." default:"1000"`
-	MaxWaypoints int     `help:"Max sampled waypoints along the route." default:"5"`
-	Limit        int     `help:"Max results per waypoint (1-20)." default:"5"`
-	Language     string  `help:"BCP-47 language code (e.g. en, en-US)."`
-	Region       string  `help:"CLDR region code (e.g. US, DE)."`
+	Query        string   `arg:"" name:"query" help:"Search text."`
+	From         string   `help:"Origin location (address or place name)."`
+	To           string   `help:"Destination location (address or place name)."`
+	Mode         string   `help:"Travel mode: DRIVE, WALK, BICYCLE, TWO_WHEELER, TRANSIT." default:"DRIVE"`
+	RadiusM      float64  `help:"Search radius in meters." default:"1000"`
+	MaxWaypoints int      `help:"Max sampled waypoints along the route." default:"5"`
+	Limit        int      `help:"Max results per waypoint (1-20)." default:"5"`
+	Language     string   `help:"BCP-47 language code (e.g. en, en-US)."`
+	Region       string   `help:"CLDR region code (e.g. US, DE)."`
+	DetourTime   bool     `help:"Estimate detour time per place (extra Directions API calls)." name:"detour-time"`
+	ExtraCompute []string `help:"Routes API extraComputations value (e.g. TOLLS). Repeatable." name:"extra-computation"`
+	Reference    []string `help:"Also compute a labeled reference route: FUEL_EFFICIENT, SHORTER_DISTANCE. Repeatable." name:"reference-route"`
+	Steps        bool     `help:"Include turn-by-turn steps for the route."`
+	RouteToken   bool     `help:"Request a Navigation SDK route token (DRIVE, TWO_WHEELER)." name:"route-token"`
+	Alternatives bool     `help:"Also compute alternative routes; places are still searched along the default."`
+	Polyline     bool     `help:"Print only the route's encoded polyline (skips the place searches)."`
+	Format       string   `help:"Output format: text or geojson (route LineStrings and place Points)." enum:"text,geojson" default:"text"`
 }
 
 // Run executes the route command.
 func (c *RouteCmd) Run(app *App) error {
+	if c.Polyline && c.Format != "text" {
+		return goplaces.ValidationError{Field: "polyline", Message: "cannot be combined with --format " + c.Format}
+	}
 	request := goplaces.RouteRequest{
-		Query:        c.Query,
-		From:         c.From,
-		To:           c.To,
-		Mode:         c.Mode,
-		RadiusM:      c.RadiusM,
-		MaxWaypoints: c.MaxWaypoints,
-		Limit:        c.Limit,
-		Language:     c.Language,
-		Region:       c.Region,
+		Query:                    c.Query,
+		From:                     c.From,
+		To:                       c.To,
+		Mode:                     c.Mode,
+		RadiusM:                  c.RadiusM,
+		MaxWaypoints:             c.MaxWaypoints,
+		Limit:                    c.Limit,
+		Language:                 c.Language,
+		Region:                   c.Region,
+		DetourTime:               c.DetourTime,
+		ExtraComputations:        c.ExtraCompute,
+		RequestedReferenceRoutes: c.Reference,
+		Steps:                    c.Steps,
+		IncludeRouteToken:        c.RouteToken,
+		ComputeAlternativeRoutes: c.Alternatives,
+		PolylineOnly:             c.Polyline,
 	}
 
 	response, err := app.client.Route(context.Background(), request)
@@ -39,10 +57,25 @@ func (c *RouteCmd) Run(app *App) error {
 		return err
 	}
 
+	for _, warning := range response.Warnings {
+		_, _ = fmt.Fprintln(app.err, "warning:", warning)
+	}
+
+	if c.Polyline {
+		_, err = fmt.Fprintln(app.out, response.EncodedPolyline)
+		return err
+	}
+	if c.Format == formatGeoJSON {
+		collection, err := routeGeoJSON(response)
+		if err != nil {
+			return err
+		}
+		return app.writeJSON(collection)
+	}
 	if app.json {
-		return writeJSON(app.out, response)
+		return app.writeJSON(response)
 	}
 
-	_, err = fmt.Fprintln(app.out, renderRoute(app.color, response))
+	_, err = fmt.Fprintln(app.out, renderRoute(app.color, app.numbers, response))
 	return err
 }
@@ -1,12 +1,14 @@
 package cli
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/alecthomas/kong"
 	"github.com/steipete/goplaces"
@@ -14,11 +16,18 @@ import (
 
 // App wires CLI output and API access.
 type App struct {
-	client *goplaces.Client
-	out    io.Writer
-	err    io.Writer
-	json   bool
-	color  Color
+	client  *goplaces.Client
+	out     io.Writer
+	err     io.Writer
+	json    bool
+	compact bool
+	// jsonStyle is jsonStyleSnake (struct tags as-is) or jsonStyleCamel.
+	jsonStyle  string
+	color      Color
+	numbers    numberFormat
+	now        func() time.Time
+	sessionDir string
+	apiKey     string
 }
 
 // Run executes the CLI with the provided arguments.
@@ -63,25 +72,47 @@ func Run(args []string, stdout io.Writer, stderr io.Writer) int {
 		_, _ = fmt.Fprintln(stderr, err)
 		return 2
 	}
+	if root.Global.JSONCompact {
+		root.Global.JSON = true
+	}
 	if root.Global.JSON {
 		// JSON output should never include ANSI escapes.
 		root.Global.NoColor = true
 	}
 
-	client := goplaces.NewClient(goplaces.Options{
+	client, err := goplaces.NewClientWithError(goplaces.Options{
 		APIKey:            root.Global.APIKey,
 		BaseURL:           root.Global.BaseURL,
 		RoutesBaseURL:     root.Global.RoutesBaseURL,
 		DirectionsBaseURL: root.Global.DirectionsBaseURL,
+		GeocodeBaseURL:    root.Global.GeocodeBaseURL,
 		Timeout:           root.Global.Timeout,
+		AutoRegion:        root.Global.AutoRegion,
+		AutoUnits:         root.Global.AutoUnits,
+		WarningsAreErrors: root.Global.StrictWarnings,
+		MaxRetries:        root.Global.MaxRetries,
 	})
+	if err != nil {
+		return handleError(stderr, err)
+	}
+
+	numbers, err := parseNumberLocale(root.Global.Locale)
+	if err != nil {
+		return handleError(stderr, err)
+	}
 
 	app := &App{
-		client: client,
-		out:    stdout,
-		err:    stderr,
-		json:   root.Global.JSON,
-		color:  NewColor(colorEnabled(root.Global.NoColor)),
+		client:     client,
+		out:        stdout,
+		err:        stderr,
+		json:       root.Global.JSON,
+		compact:    root.Global.JSONCompact,
+		jsonStyle:  root.Global.JSONStyle,
+		color:      NewColor(colorEnabled(root.Global.NoColor)),
+		numbers:    numbers,
+		now:        time.Now,
+		sessionDir: defaultSessionDir(),
+		apiKey:     root.Global.APIKey,
 	}
 
 	ctx.Bind(app)
@@ -118,12 +149,16 @@ func parseWithExit(parser *kong.Kong, args []string, exitCode *int) (ctx *kong.C
 
 // Run executes the search command.
 func (c *SearchCmd) Run(app *App) error {
+	if err := validateHead(c.Head); err != nil {
+		return err
+	}
 	request := goplaces.SearchRequest{
-		Query:     c.Query,
-		Limit:     c.Limit,
-		PageToken: c.PageToken,
-		Language:  c.Language,
-		Region:    c.Region,
+		Query:                  c.Query,
+		Limit:                  c.Limit,
+		PageToken:              c.PageToken,
+		Language:               c.Language,
+		Region:                 c.Region,
+		IncludeEVChargeOptions: c.EVAvailable,
 	}
 
 	filters := goplaces.Filters{}
@@ -163,27 +198,53 @@ func (c *SearchCmd) Run(app *App) error {
 		}
 	}
 
+	if c.CountOnly {
+		return app.writePlaceCount(app.client.SearchIterator(request), c.EVAvailable, c.Head, c.FailOnEmpty)
+	}
+	if c.Format == formatNDJSON {
+		written, err := app.streamPlacesNDJSON(app.client.SearchIterator(request), c.EVAvailable, c.Head)
+		if err != nil {
+			return err
+		}
+		return failIfEmpty(c.FailOnEmpty, written)
+	}
+
 	response, err := app.client.Search(context.Background(), request)
 	if err != nil {
 		return err
 	}
+	if c.EVAvailable {
+		response.Results = filterEVAvailable(response.Results)
+	}
+	response.Results = headResults(response.Results, c.Head)
 
+	if c.Format == formatGeoJSON {
+		if err := app.writePlacesGeoJSON(response.Results); err != nil {
+			return err
+		}
+		return failIfEmpty(c.FailOnEmpty, len(response.Results))
+	}
 	if app.json {
-		if err := writeJSON(app.out, response.Results); err != nil {
+		if err := app.writeJSON(response.Results); err != nil {
 			return err
 		}
 		if response.NextPageToken != "" {
 			_, _ = fmt.Fprintln(app.err, "next_page_token:", response.NextPageToken)
 		}
-		return nil
+		return failIfEmpty(c.FailOnEmpty, len(response.Results))
 	}
 
-	_, err = fmt.Fprintln(app.out, renderSearch(app.color, response))
-	return err
+	if _, err := fmt.Fprintln(app.out, renderSearch(app.color, app.numbers, response)); err != nil {
+		return err
+	}
+	return failIfEmpty(c.FailOnEmpty, len(response.Results))
 }
 
 // Run executes the autocomplete command.
 func (c *AutocompleteCmd) Run(app *App) error {
+	if err := validateHead(c.Head); err != nil {
+		return err
+	}
 	request := goplaces.AutocompleteRequest{
 		Input:        c.Input,
 		Limit:        c.Limit,
@@ -191,6 +252,16 @@ func (c *AutocompleteCmd) Run(app *App) error {
 		Language:     c.Language,
 		Region:       c.Region,
 	}
+	var session savedSession
+	sessionFrom := ""
+	if c.SaveSession && request.SessionToken == "" {
+		var err error
+		session, sessionFrom, err = continueSession(app.sessionDir, c.Input, app.now())
+		if err != nil {
+			return err
+		}
+		request.SessionToken = session.Token
+	}
 
 	if c.Lat != nil || c.Lng != nil || c.RadiusM != nil {
 		if c.Lat == nil || c.Lng == nil || c.RadiusM == nil {
@@ -207,9 +278,22 @@ func (c *AutocompleteCmd) Run(app *App) error {
 	if err != nil {
 		return err
 	}
+	if session.Token != "" {
+		placeIDs := make([]string, 0, len(response.Suggestions))
+		for _, suggestion := range response.Suggestions {
+			placeIDs = append(placeIDs, suggestion.PlaceID)
+		}
+		if err := recordSession(app.sessionDir, session, sessionFrom, c.Input, placeIDs); err != nil {
+			return fmt.Errorf("save session: %w", err)
+		}
+	}
+	response.Suggestions = headResults(response.Suggestions, c.Head)
 
+	if c.Format == formatNDJSON {
+		return writeNDJSON(app, response.Suggestions)
+	}
 	if app.json {
-		return writeJSON(app.out, response.Suggestions)
+		return app.writeJSON(response.Suggestions)
 	}
 
 	_, err = fmt.Fprintln(app.out, renderAutocomplete(app.color, response))
@@ -218,6 +302,9 @@ func (c *AutocompleteCmd) Run(app *App) error {
 
 // Run executes the nearby command.
 func (c *NearbyCmd) Run(app *App) error {
+	if err := validateHead(c.Head); err != nil {
+		return err
+	}
 	if c.Lat == nil || c.Lng == nil || c.RadiusM == nil {
 		return goplaces.ValidationError{Field: "location_restriction", Message: "lat, lng, radius required"}
 	}
@@ -228,74 +315,147 @@ func (c *NearbyCmd) Run(app *App) error {
 			Lng:     *c.Lng,
 			RadiusM: *c.RadiusM,
 		},
-		Limit:         c.Limit,
-		IncludedTypes: c.Type,
-		ExcludedTypes: c.ExcludeType,
-		Language:      c.Language,
-		Region:        c.Region,
+		Limit:                  c.Limit,
+		IncludedTypes:          c.Type,
+		ExcludedTypes:          c.ExcludeType,
+		Language:               c.Language,
+		Region:                 c.Region,
+		IncludeEVChargeOptions: c.EVAvailable,
+	}
+
+	if c.CountOnly {
+		return app.writePlaceCount(app.client.NearbyIterator(request), c.EVAvailable, c.Head, c.FailOnEmpty)
+	}
+	if c.Format == formatNDJSON {
+		written, err := app.streamPlacesNDJSON(app.client.NearbyIterator(request), c.EVAvailable, c.Head)
+		if err != nil {
+			return err
+		}
+		return failIfEmpty(c.FailOnEmpty, written)
 	}
 
 	response, err := app.client.NearbySearch(context.Background(), request)
 	if err != nil {
 		return err
 	}
+	if c.EVAvailable {
+		response.Results = filterEVAvailable(response.Results)
+	}
+	response.Results = headResults(response.Results, c.Head)
 
+	if c.Format == formatGeoJSON {
+		if err := app.writePlacesGeoJSON(response.Results); err != nil {
+			return err
+		}
+		return failIfEmpty(c.FailOnEmpty, len(response.Results))
+	}
 	if app.json {
-		if err := writeJSON(app.out, response.Results); err != nil {
+		if err := app.writeJSON(response.Results); err != nil {
 			return err
 		}
 		if response.NextPageToken != "" {
 			_, _ = fmt.Fprintln(app.err, "next_page_token:", response.NextPageToken)
 		}
-		return nil
+		return failIfEmpty(c.FailOnEmpty, len(response.Results))
 	}
 
-	_, err = fmt.Fprintln(app.out, renderNearby(app.color, response))
-	return err
+	if _, err := fmt.Fprintln(app.out, renderNearby(app.color, app.numbers, response, c.TypeDisplay)); err != nil {
+		return err
+	}
+	return failIfEmpty(c.FailOnEmpty, len(response.Results))
 }
 
 // Run executes the details command.
 func (c *DetailsCmd) Run(app *App) error {
-	response, err := app.client.DetailsWithOptions(context.Background(), goplaces.DetailsRequest{
-		PlaceID:        c.PlaceID,
-		Language:       c.Language,
-		Region:         c.Region,
-		IncludeReviews: c.Reviews,
-		IncludePhotos:  c.Photos,
-	})
+	request := goplaces.DetailsRequest{
+		PlaceID:                 c.PlaceID,
+		Language:                c.Language,
+		Region:                  c.Region,
+		IncludeReviews:          c.Reviews,
+		IncludePhotos:           c.Photos,
+		IncludeEditorialSummary: c.Summary,
+	}
+	sessionFile := ""
+	if c.Session {
+		if session, path, ok := findPlaceSession(app.sessionDir, c.PlaceID, app.now()); ok {
+			request.SessionToken = session.Token
+			sessionFile = path
+		} else {
+			_, _ = fmt.Fprintln(app.err, "warning: no active autocomplete session; fetching details without one")
+		}
+	}
+
+	response, err := app.client.DetailsWithOptions(context.Background(), request)
 	if err != nil {
 		return err
 	}
+	if sessionFile != "" {
+		// A details call ends the session; the next autocomplete starts fresh.
+		if err := clearSession(sessionFile); err != nil {
+			return err
+		}
+	}
 
 	if app.json {
-		return writeJSON(app.out, response)
+		return app.writeJSON(response)
 	}
 
-	_, err = fmt.Fprintln(app.out, renderDetails(app.color, response))
+	_, err = fmt.Fprintln(app.out, renderDetails(app.color, app.numbers, response))
 	return err
 }
 
 // Run executes the photo command.
 func (c *PhotoCmd) Run(app *App) error {
-	response, err := app.client.PhotoMedia(context.Background(), goplaces.PhotoMediaRequest{
+	request := goplaces.PhotoMediaRequest{
 		Name:        c.Name,
 		MaxWidthPx:  c.MaxWidthPx,
 		MaxHeightPx: c.MaxHeightPx,
-	})
+	}
+	if c.Output != "" {
+		return c.download(app, request)
+	}
+
+	response, err := app.client.PhotoMedia(context.Background(), request)
 	if err != nil {
 		return err
 	}
 
 	if app.json {
-		return writeJSON(app.out, response)
+		return app.writeJSON(response)
 	}
 
 	_, err = fmt.Fprintln(app.out, renderPhoto(app.color, response))
 	return err
 }
 
+// download saves the image bytes to --output.
+func (c *PhotoCmd) download(app *App, request goplaces.PhotoMediaRequest) error {
+	photo, err := app.client.FetchPhoto(context.Background(), request, goplaces.PhotoOptions{})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.Output, photo.Data, 0o644); err != nil {
+		return fmt.Errorf("write photo: %w", err)
+	}
+	if app.json {
+		return app.writeJSON(photoDownload{PhotoData: photo, Path: c.Output, Bytes: len(photo.Data)})
+	}
+	_, err = fmt.Fprintf(app.out, "Saved %d bytes to %s\n", len(photo.Data), c.Output)
+	return err
+}
+
+// photoDownload is the --json report for photo --output.
+type photoDownload struct {
+	goplaces.PhotoData
+	Path  string `json:"path"`
+	Bytes int    `json:"bytes"`
+}
+
 // Run executes the resolve command.
 func (c *ResolveCmd) Run(app *App) error {
+	if err := validateHead(c.Head); err != nil {
+		return err
+	}
 	request := goplaces.LocationResolveRequest{
 		LocationText: c.LocationText,
 		Limit:        c.Limit,
@@ -307,15 +467,122 @@ func (c *ResolveCmd) Run(app *App) error {
 	if err != nil {
 		return err
 	}
+	response.Results = headResults(response.Results, c.Head)
 
+	if c.Format == formatNDJSON {
+		return writeNDJSON(app, response.Results)
+	}
 	if app.json {
-		return writeJSON(app.out, response.Results)
+		return app.writeJSON(response.Results)
 	}
 
 	_, err = fmt.Fprintln(app.out, renderResolve(app.color, response))
 	return err
 }
 
+// filterEVAvailable keeps places reporting at least one free connector.
+// Places without live availability are dropped rather than guessed at.
+func filterEVAvailable(places []goplaces.PlaceSummary) []goplaces.PlaceSummary {
+	filtered := make([]goplaces.PlaceSummary, 0, len(places))
+	for _, place := range places {
+		if hasFreeConnector(place) {
+			filtered = append(filtered, place)
+		}
+	}
+	return filtered
+}
+
+func hasFreeConnector(place goplaces.PlaceSummary) bool {
+	available, known := place.EVChargeOptions.AvailableConnectors()
+	return known && available > 0
+}
+
+func validateHead(n int) error {
+	if n < 0 {
+		return goplaces.ValidationError{Field: "head", Message: "must be >= 0"}
+	}
+	return nil
+}
+
+// writePlaceCount prints only how many places --count-only would list: every
+// page is fetched, then client-side filters and --head apply as usual.
+func (a *App) writePlaceCount(it *goplaces.Iterator[goplaces.PlaceSummary], evAvailable bool, head int, failOnEmpty bool) error {
+	var places []goplaces.PlaceSummary
+	for {
+		place, err := it.Next(context.Background())
+		if errors.Is(err, goplaces.ErrIteratorDone) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		places = append(places, place)
+	}
+	if evAvailable {
+		places = filterEVAvailable(places)
+	}
+	count := len(headResults(places, head))
+	if _, err := fmt.Fprintln(a.out, count); err != nil {
+		return err
+	}
+	return failIfEmpty(failOnEmpty, count)
+}
+
+// errNoResults makes --fail-on-empty exit 1 after printing an empty result.
+var errNoResults = errors.New("no results")
+
+func failIfEmpty(enabled bool, count int) error {
+	if enabled && count == 0 {
+		return errNoResults
+	}
+	return nil
+}
+
+// headResults trims output to the first n items (--head). Unlike --limit it
+// never changes what is fetched, so it applies after client-side filters.
+func headResults[T any](items []T, n int) []T {
+	if n > 0 && len(items) > n {
+		return items[:n]
+	}
+	return items
+}
+
+// writeJSON writes indented JSON, or a single line with --json-compact.
+// --json-style camel rewrites keys after encoding.
+func (a *App) writeJSON(value any) error {
+	if a.jsonStyle != jsonStyleCamel {
+		if a.compact {
+			return writeCompactJSON(a.out, value)
+		}
+		return writeJSON(a.out, value)
+	}
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if payload, err = camelCaseJSON(payload); err != nil {
+		return err
+	}
+	if !a.compact {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, payload, "", "  "); err != nil {
+			return err
+		}
+		payload = indented.Bytes()
+	}
+	_, err = a.out.Write(append(payload, '\n'))
+	return err
+}
+
+func writeCompactJSON(writer io.Writer, value any) error {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(append(payload, '\n'))
+	return err
+}
+
 func writeJSON(writer io.Writer, value any) error {
 	payload, err := json.MarshalIndent(value, "", "  ")
 	if err != nil {
@@ -0,0 +1,190 @@
+package cli
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// sessionTTL keeps saved tokens inside Google's few-minute session window.
+const sessionTTL = 3 * time.Minute
+
+const (
+	sessionFilePrefix = "session-"
+	sessionFileSuffix = ".json"
+)
+
+// savedSession is an autocomplete session shared between CLI invocations. It
+// is stored under its input, so "co" then "coffee" continue one session.
+type savedSession struct {
+	Token   string    `json:"token"`
+	Created time.Time `json:"created"`
+	Input   string    `json:"input"`
+	// PlaceIDs are the places suggested so far; details --session finds the
+	// session by its place ID.
+	PlaceIDs []string `json:"place_ids,omitempty"`
+}
+
+// defaultSessionDir is private to the user, unlike the shared temp dir, so
+// other local users cannot read tokens or plant files there. Empty when the
+// platform has no user cache dir.
+func defaultSessionDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "goplaces", "sessions")
+}
+
+// sessionPath names the file for input: a hash, so any text makes a safe name.
+func sessionPath(dir, input string) string {
+	sum := sha256.Sum256([]byte(normalizeSessionInput(input)))
+	return filepath.Join(dir, sessionFilePrefix+hex.EncodeToString(sum[:8])+sessionFileSuffix)
+}
+
+func normalizeSessionInput(input string) string {
+	return strings.ToLower(strings.TrimSpace(input))
+}
+
+// continueSession returns the unexpired session saved for input or its
+// longest saved prefix, and the file it came from; otherwise a new session
+// and an empty path.
+func continueSession(dir, input string, now time.Time) (savedSession, string, error) {
+	if dir != "" {
+		// Trim whole runes so no candidate prefix splits a multi-byte character.
+		key := []rune(normalizeSessionInput(input))
+		for n := len(key); n > 0; n-- {
+			path := sessionPath(dir, string(key[:n]))
+			if session, ok := loadSession(path, now); ok {
+				return session, path, nil
+			}
+		}
+	}
+	token, err := newSessionToken()
+	if err != nil {
+		return savedSession{}, "", err
+	}
+	return savedSession{Token: token, Created: now}, "", nil
+}
+
+// findPlaceSession returns the newest unexpired session that suggested placeID.
+func findPlaceSession(dir, placeID string, now time.Time) (savedSession, string, bool) {
+	if dir == "" {
+		return savedSession{}, "", false
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return savedSession{}, "", false
+	}
+	var found savedSession
+	foundPath := ""
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, sessionFilePrefix) || !strings.HasSuffix(name, sessionFileSuffix) {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		session, ok := loadSession(path, now)
+		if !ok || !slices.Contains(session.PlaceIDs, placeID) {
+			continue
+		}
+		if foundPath == "" || session.Created.After(found.Created) {
+			found, foundPath = session, path
+		}
+	}
+	return found, foundPath, foundPath != ""
+}
+
+// recordSession saves session under input with the new suggestions, and drops
+// the prefix file it was continued from.
+func recordSession(dir string, session savedSession, from, input string, suggestions []string) error {
+	if dir == "" {
+		return errors.New("no user cache directory for sessions")
+	}
+	session.Input = normalizeSessionInput(input)
+	for _, placeID := range suggestions {
+		if placeID != "" && !slices.Contains(session.PlaceIDs, placeID) {
+			session.PlaceIDs = append(session.PlaceIDs, placeID)
+		}
+	}
+	path := sessionPath(dir, input)
+	if err := saveSession(path, session); err != nil {
+		return err
+	}
+	if from != "" && from != path {
+		return clearSession(from)
+	}
+	return nil
+}
+
+// loadSession returns the saved session unless it is missing, unreadable, or expired.
+func loadSession(path string, now time.Time) (savedSession, bool) {
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		return savedSession{}, false
+	}
+	var session savedSession
+	if err := json.Unmarshal(payload, &session); err != nil || session.Token == "" {
+		return savedSession{}, false
+	}
+	if now.Sub(session.Created) > sessionTTL {
+		return savedSession{}, false
+	}
+	return session, true
+}
+
+// saveSession writes a fresh temp file (created exclusively, mode 0600) and
+// renames it over path, so an existing file or symlink there is replaced,
+// never written through.
+func saveSession(path string, session savedSession) error {
+	payload, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(dir, sessionFilePrefix+"*.tmp")
+	if err != nil {
+		return err
+	}
+	_, writeErr := file.Write(payload)
+	if err := errors.Join(writeErr, file.Close()); err != nil {
+		_ = os.Remove(file.Name())
+		return err
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		_ = os.Remove(file.Name())
+		return err
+	}
+	return nil
+}
+
+// clearSession ends the saved session; a missing file is not an error.
+func clearSession(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// newSessionToken returns a random UUIDv4, the format Google recommends.
+func newSessionToken() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAutocompleteDetailsSavedSession(t *testing.T) {
+	isolateSessionDir(t)
+	var autocompleteTokens []string
+	var detailsToken string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/places:autocomplete":
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			token, _ := body["sessionToken"].(string)
+			autocompleteTokens = append(autocompleteTokens, token)
+			_, _ = w.Write([]byte(`{"suggestions": [{"placePrediction": {"placeId": "place-1"}}]}`))
+		case strings.HasPrefix(r.URL.Path, "/places/"):
+			detailsToken = r.URL.Query().Get("sessionToken")
+			_, _ = w.Write([]byte(`{"id": "place-1"}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	run := func(args ...string) {
+		t.Helper()
+		var stdout, stderr bytes.Buffer
+		args = append(args, "--api-key", "test-key", "--base-url", server.URL, "--json")
+		if exitCode := Run(args, &stdout, &stderr); exitCode != 0 {
+			t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+		}
+	}
+	run("autocomplete", "co", "--save-session")
+	run("autocomplete", "cof", "--save-session")
+	run("details", "place-1", "--session")
+
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	if len(autocompleteTokens) != 2 || !uuid.MatchString(autocompleteTokens[0]) {
+		t.Fatalf("expected generated session token, got %#v", autocompleteTokens)
+	}
+	if autocompleteTokens[1] != autocompleteTokens[0] || detailsToken != autocompleteTokens[0] {
+		t.Fatalf("expected one session across calls: autocomplete=%#v details=%q", autocompleteTokens, detailsToken)
+	}
+	if entries, err := os.ReadDir(defaultSessionDir()); err != nil || len(entries) != 0 {
+		t.Fatalf("expected details to end the session, got %d files (err=%v)", len(entries), err)
+	}
+}
+
+func TestDetailsSessionMissingWarns(t *testing.T) {
+	isolateSessionDir(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("sessionToken") != "" {
+			t.Fatalf("unexpected session token: %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"id": "place-1"}`))
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	exitCode := Run([]string{"details", "place-1", "--session", "--api-key", "test-key", "--base-url", server.URL, "--json"}, &stdout, &stderr)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "no active autocomplete session") {
+		t.Fatalf("expected warning, got %q", stderr.String())
+	}
+}
+
+// isolateSessionDir points os.UserCacheDir at a fresh directory.
+func isolateSessionDir(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("LocalAppData", dir)
+}
+
+func TestSessionKeyedByInput(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	first, from, err := continueSession(dir, "Co", now)
+	if err != nil || from != "" {
+		t.Fatalf("expected a new session, got %q (err=%v)", from, err)
+	}
+	if err := recordSession(dir, first, from, "Co", []string{"p1"}); err != nil {
+		t.Fatalf("recordSession error: %v", err)
+	}
+
+	next, from, err := continueSession(dir, "coffee", now.Add(time.Minute))
+	if err != nil || next.Token != first.Token || from != sessionPath(dir, "co") {
+		t.Fatalf("expected the prefix session to continue, got %#v from %q (err=%v)", next, from, err)
+	}
+	if err := recordSession(dir, next, from, "coffee", []string{"p1", "p2"}); err != nil {
+		t.Fatalf("recordSession error: %v", err)
+	}
+	if _, err := os.Stat(from); !os.IsNotExist(err) {
+		t.Fatalf("expected the prefix file to be replaced, stat err=%v", err)
+	}
+	session, path, ok := findPlaceSession(dir, "p2", now.Add(time.Minute))
+	if !ok || path != sessionPath(dir, "coffee") || session.Token != first.Token || len(session.PlaceIDs) != 2 {
+		t.Fatalf("unexpected session for p2: %#v at %q", session, path)
+	}
+
+	other, _, err := continueSession(dir, "tea", now)
+	if err != nil || other.Token == first.Token {
+		t.Fatalf("expected a separate session for unrelated input, got %#v (err=%v)", other, err)
+	}
+	if _, _, ok := findPlaceSession(dir, "p3", now); ok {
+		t.Fatalf("expected no session for an unsuggested place")
+	}
+}
+
+func TestSessionPrefixMultiByte(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	first, from, err := continueSession(dir, "Café", now)
+	if err != nil {
+		t.Fatalf("continueSession error: %v", err)
+	}
+	if err := recordSession(dir, first, from, "Café", nil); err != nil {
+		t.Fatalf("recordSession error: %v", err)
+	}
+	next, from, err := continueSession(dir, "cafés ñ", now)
+	if err != nil || next.Token != first.Token || from != sessionPath(dir, "café") {
+		t.Fatalf("expected the multi-byte prefix session to continue, got %#v from %q (err=%v)", next, from, err)
+	}
+}
+
+func TestSaveSessionReplacesSymlink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(t.TempDir(), "victim")
+	if err := os.WriteFile(target, []byte("keep"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	path := sessionPath(dir, "co")
+	if err := os.Symlink(target, path); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+	if err := saveSession(path, savedSession{Token: "tok", Created: time.Now()}); err != nil {
+		t.Fatalf("saveSession error: %v", err)
+	}
+	if payload, _ := os.ReadFile(target); string(payload) != "keep" {
+		t.Fatalf("session write followed the symlink: %q", payload)
+	}
+	info, err := os.Lstat(path)
+	if err != nil || info.Mode()&os.ModeSymlink != 0 || info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected a private regular file, got %v (err=%v)", info, err)
+	}
+}
+
+func TestLoadSessionExpires(t *testing.T) {
+	path := sessionPath(t.TempDir(), "co")
+	created := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	if err := saveSession(path, savedSession{Token: "tok", Created: created}); err != nil {
+		t.Fatalf("saveSession error: %v", err)
+	}
+	if session, ok := loadSession(path, created.Add(sessionTTL)); !ok || session.Token != "tok" {
+		t.Fatalf("expected session within TTL, got %#v", session)
+	}
+	if _, ok := loadSession(path, created.Add(sessionTTL+time.Second)); ok {
+		t.Fatalf("expected expired session")
+	}
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, ok := loadSession(path, created); ok {
+		t.Fatalf("expected corrupt session to be ignored")
+	}
+	if err := clearSession(path); err != nil {
+		t.Fatalf("clearSession error: %v", err)
+	}
+	if err := clearSession(path); err != nil {
+		t.Fatalf("clearSession on missing file should succeed: %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/steipete/goplaces"
+)
+
+const formatCSV = "csv"
+
+// ValidateCmd checks a file of addresses or "lat,lng" lines with the Geocoding API.
+type ValidateCmd struct {
+	File        string `arg:"" name:"file" help:"File with one address or lat,lng per line (- for stdin)."`
+	Format      string `help:"Report format: table or csv (--json for JSON)." enum:"table,csv" default:"table"`
+	Concurrency int    `help:"Max concurrent Geocoding requests." default:"4"`
+	FailFast    bool   `help:"Stop at the first request error instead of reporting it per line." name:"fail-fast"`
+	Quiet       bool   `help:"Suppress progress on stderr."`
+}
+
+// validatedLine ties a check to its 1-based line number in the input file.
+type validatedLine struct {
+	Line int `json:"line"`
+	goplaces.LocationCheck
+}
+
+// Run executes the validate command.
+func (c *ValidateCmd) Run(app *App) error {
+	numbers, inputs, err := readValidateInputs(c.File)
+	if err != nil {
+		return err
+	}
+	if len(inputs) == 0 {
+		return goplaces.ValidationError{Field: "file", Message: "no addresses or coordinates found"}
+	}
+
+	opts := goplaces.BatchOptions{StopOnError: c.FailFast, Concurrency: c.Concurrency}
+	if !c.Quiet {
+		opts.Progress = func(done, total int) {
+			_, _ = fmt.Fprintf(app.err, "\rchecked %d/%d", done, total)
+			if done == total {
+				_, _ = fmt.Fprintln(app.err)
+			}
+		}
+	}
+	checks, err := app.client.ValidateLocations(context.Background(), inputs, opts)
+	if err != nil {
+		return err
+	}
+
+	lines := make([]validatedLine, len(checks))
+	failed := 0
+	for i, check := range checks {
+		lines[i] = validatedLine{Line: numbers[i], LocationCheck: check}
+		if check.Status != goplaces.LocationOK {
+			failed++
+		}
+	}
+
+	switch {
+	case app.json:
+		err = app.writeJSON(lines)
+	case c.Format == formatCSV:
+		err = writeValidateCSV(app.out, app.numbers, lines)
+	default:
+		_, err = io.WriteString(app.out, renderValidate(app.color, lines))
+	}
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d locations did not validate cleanly", failed, len(lines))
+	}
+	return nil
+}
+
+// readValidateInputs skips blank lines and # comments, keeping line numbers.
+func readValidateInputs(path string) ([]int, []string, error) {
+	var reader io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		reader = file
+	}
+
+	var numbers []int
+	var inputs []string
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		numbers = append(numbers, line)
+		inputs = append(inputs, text)
+	}
+	return numbers, inputs, scanner.Err()
+}
+
+func writeValidateCSV(writer io.Writer, numbers numberFormat, lines []validatedLine) error {
+	out := csv.NewWriter(writer)
+	out.Comma = numbers.csvComma()
+	_ = out.Write([]string{"line", "input", "status", "address", "lat", "lng", "place_id", "candidates", "reason"})
+	for _, line := range lines {
+		lat, lng := "", ""
+		if line.Location != nil {
+			lat = numbers.float(line.Location.Lat, -1)
+			lng = numbers.float(line.Location.Lng, -1)
+		}
+		_ = out.Write([]string{
+			strconv.Itoa(line.Line), line.Input, line.Status, line.Address, lat, lng,
+			line.PlaceID, strconv.Itoa(line.Candidates), line.Reason,
+		})
+	}
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newValidateServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("address") == "nowhere" {
+			_, _ = w.Write([]byte(`{"status": "ZERO_RESULTS", "results": []}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "OK", "results": [{"formatted_address": "1 Main St, Town", "geometry": {"location": {"lat": 1.5, "lng": 2}}}]}`))
+	}))
+}
+
+func writeValidateFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "addresses.txt")
+	if err := os.WriteFile(path, []byte("# header\n1 Main St\n\nnowhere\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return path
+}
+
+func TestRunValidateTable(t *testing.T) {
+	server := newValidateServer()
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	exitCode := Run([]string{"validate", writeValidateFile(t), "--api-key", "test-key", "--geocode-base-url", server.URL, "--no-color"}, &stdout, &stderr)
+	if exitCode != 1 {
+		t.Fatalf("expected exit code 1 for an unresolved line, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	output := stdout.String()
+	if !strings.Contains(output, "Validated 1/2") || !strings.Contains(output, "2     OK         1 Main St  1 Main St, Town") {
+		t.Fatalf("unexpected table: %s", output)
+	}
+	if !strings.Contains(output, "4     NOT_FOUND  nowhere    -") {
+		t.Fatalf("missing not-found row: %s", output)
+	}
+	if !strings.Contains(stderr.String(), "checked 2/2") || !strings.Contains(stderr.String(), "1 of 2 locations") {
+		t.Fatalf("unexpected stderr: %q", stderr.String())
+	}
+}
+
+func TestRunValidateCSVAndJSON(t *testing.T) {
+	server := newValidateServer()
+	defer server.Close()
+	path := writeValidateFile(t)
+
+	var stdout, stderr bytes.Buffer
+	Run([]string{"validate", path, "--format", "csv", "--quiet", "--api-key", "test-key", "--geocode-base-url", server.URL}, &stdout, &stderr)
+	if !strings.HasPrefix(stdout.String(), "line,input,status,address,lat,lng,place_id,candidates,reason\n2,1 Main St,OK,\"1 Main St, Town\",1.5,2,,1,\n") {
+		t.Fatalf("unexpected csv: %q", stdout.String())
+	}
+	if stderr.String() != "1 of 2 locations did not validate cleanly\n" {
+		t.Fatalf("expected no progress with --quiet, got %q", stderr.String())
+	}
+
+	stdout.Reset()
+	Run([]string{"validate", path, "--json", "--quiet", "--api-key", "test-key", "--geocode-base-url", server.URL}, &stdout, &stderr)
+	var lines []validatedLine
+	if err := json.Unmarshal(stdout.Bytes(), &lines); err != nil {
+		t.Fatalf("decode json: %v (%s)", err, stdout.String())
+	}
+	if len(lines) != 2 || lines[1].Line != 4 || lines[1].Status != "NOT_FOUND" {
+		t.Fatalf("unexpected json lines: %#v", lines)
+	}
+}
+
+func TestRunValidateEmptyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.txt")
+	if err := os.WriteFile(path, []byte("\n# nothing\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	var stdout, stderr bytes.Buffer
+	if exitCode := Run([]string{"validate", path, "--api-key", "test-key"}, &stdout, &stderr); exitCode != 2 {
+		t.Fatalf("expected exit code 2, got %d (stderr=%s)", exitCode, stderr.String())
+	}
+	if exitCode := Run([]string{"validate", filepath.Join(t.TempDir(), "missing.txt"), "--api-key", "test-key"}, &stdout, &stderr); exitCode != 1 {
+		t.Fatalf("expected exit code 1 for a missing file, got %d", exitCode)
+	}
+}
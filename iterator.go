@@ -0,0 +1,82 @@
+package goplaces
+
+import "context"
+
+// Iterator walks paginated results one item at a time, following page tokens.
+//
+//	it := client.SearchIterator(req)
+//	for {
+//		place, err := it.Next(ctx)
+//		if errors.Is(err, goplaces.ErrIteratorDone) {
+//			break
+//		}
+//		if err != nil {
+//			return err
+//		}
+//		// use place
+//	}
+type Iterator[T any] struct {
+	fetch   func(ctx context.Context, pageToken string) ([]T, string, error)
+	buffer  []T
+	token   string
+	started bool
+}
+
+func newIterator[T any](fetch func(ctx context.Context, pageToken string) ([]T, string, error)) *Iterator[T] {
+	return &Iterator[T]{fetch: fetch}
+}
+
+// Next returns the next item, or ErrIteratorDone after the last page. An
+// empty page or a repeated page token also ends iteration, so a misbehaving
+// API cannot keep Next fetching forever.
+func (it *Iterator[T]) Next(ctx context.Context) (T, error) {
+	var zero T
+	for len(it.buffer) == 0 {
+		if it.started && it.token == "" {
+			return zero, ErrIteratorDone
+		}
+		items, token, err := it.fetch(ctx, it.token)
+		if err != nil {
+			return zero, err
+		}
+		if len(items) == 0 || token == it.token {
+			token = ""
+		}
+		it.started = true
+		it.buffer = items
+		it.token = token
+	}
+	item := it.buffer[0]
+	it.buffer = it.buffer[1:]
+	return item, nil
+}
+
+// SearchIterator iterates text search results across pages.
+func (c *Client) SearchIterator(req SearchRequest) *Iterator[PlaceSummary] {
+	return newIterator(func(ctx context.Context, pageToken string) ([]PlaceSummary, string, error) {
+		page := req
+		if pageToken != "" {
+			page.PageToken = pageToken
+		}
+		response, err := c.Search(ctx, page)
+		if err != nil {
+			return nil, "", err
+		}
+		return response.Results, response.NextPageToken, nil
+	})
+}
+
+// NearbyIterator iterates nearby search results across pages.
+func (c *Client) NearbyIterator(req NearbySearchRequest) *Iterator[PlaceSummary] {
+	return newIterator(func(ctx context.Context, pageToken string) ([]PlaceSummary, string, error) {
+		page := req
+		if pageToken != "" {
+			page.PageToken = pageToken
+		}
+		response, err := c.NearbySearch(ctx, page)
+		if err != nil {
+			return nil, "", err
+		}
+		return response.Results, response.NextPageToken, nil
+	})
+}
@@ -0,0 +1,120 @@
+package goplaces
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearchIteratorTwoPages(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		switch body["pageToken"] {
+		case nil:
+			_, _ = w.Write([]byte(`{"places": [{"id": "a"}, {"id": "b"}], "nextPageToken": "page-2"}`))
+		case "page-2":
+			_, _ = w.Write([]byte(`{"places": [{"id": "c"}]}`))
+		default:
+			t.Fatalf("unexpected page token: %#v", body["pageToken"])
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	it := client.SearchIterator(SearchRequest{Query: "coffee"})
+
+	var ids []string
+	for {
+		place, err := it.Next(context.Background())
+		if errors.Is(err, ErrIteratorDone) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next error: %v", err)
+		}
+		ids = append(ids, place.PlaceID)
+	}
+	if len(ids) != 3 || ids[0] != "a" || ids[2] != "c" {
+		t.Fatalf("unexpected ids: %#v", ids)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 page fetches, got %d", calls)
+	}
+	if _, err := it.Next(context.Background()); !errors.Is(err, ErrIteratorDone) {
+		t.Fatalf("expected iterator to stay done, got %v", err)
+	}
+}
+
+func TestNearbyIteratorError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("boom"))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	it := client.NearbyIterator(NearbySearchRequest{
+		LocationRestriction: &LocationBias{Lat: 1, Lng: 2, RadiusM: 3},
+	})
+	var apiErr *APIError
+	if _, err := it.Next(context.Background()); !errors.As(err, &apiErr) {
+		t.Fatalf("expected api error, got %v", err)
+	}
+}
+
+func TestNearbyIteratorStopsOnEmptyPage(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"places": [], "nextPageToken": "next"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	it := client.NearbyIterator(NearbySearchRequest{
+		LocationRestriction: &LocationBias{Lat: 1, Lng: 2, RadiusM: 3},
+	})
+	if _, err := it.Next(context.Background()); !errors.Is(err, ErrIteratorDone) {
+		t.Fatalf("expected done after an empty page, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 page fetch, got %d", calls)
+	}
+}
+
+func TestSearchIteratorStopsOnRepeatedToken(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"places": [{"id": "a"}], "nextPageToken": "same"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	it := client.SearchIterator(SearchRequest{Query: "coffee"})
+	count := 0
+	for {
+		_, err := it.Next(context.Background())
+		if errors.Is(err, ErrIteratorDone) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next error: %v", err)
+		}
+		count++
+		if count > 10 {
+			t.Fatalf("iterator did not stop on a repeated token")
+		}
+	}
+	if count != 2 || calls != 2 {
+		t.Fatalf("expected 2 items from 2 fetches, got %d items, %d fetches", count, calls)
+	}
+}
@@ -1,6 +1,9 @@
 package goplaces
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+)
 
 func mapReviews(reviews []reviewPayload) []Review {
 	if len(reviews) == 0 {
@@ -132,3 +135,57 @@ func mapPriceLevel(value string) *int {
 	}
 	return nil
 }
+
+func mapPriceRange(payload *priceRangePayload) *PriceRange {
+	if payload == nil {
+		return nil
+	}
+	mapped := &PriceRange{}
+	if amount, currency, ok := mapMoney(payload.StartPrice); ok {
+		mapped.StartAmount = &amount
+		mapped.Currency = currency
+	}
+	if amount, currency, ok := mapMoney(payload.EndPrice); ok {
+		mapped.EndAmount = &amount
+		if mapped.Currency == "" {
+			mapped.Currency = currency
+		}
+	}
+	if mapped.StartAmount == nil && mapped.EndAmount == nil {
+		return nil
+	}
+	return mapped
+}
+
+func mapMoney(money *moneyPayload) (float64, string, bool) {
+	if money == nil {
+		return 0, "", false
+	}
+	units := int64(0)
+	if money.Units != "" {
+		parsed, err := strconv.ParseInt(money.Units, 10, 64)
+		if err != nil {
+			return 0, "", false
+		}
+		units = parsed
+	}
+	return float64(units) + float64(money.Nanos)/1e9, money.CurrencyCode, true
+}
+
+func mapEVChargeOptions(options *evChargeOptions) *EVChargeOptions {
+	if options == nil {
+		return nil
+	}
+	mapped := &EVChargeOptions{ConnectorCount: options.ConnectorCount}
+	for _, aggregation := range options.ConnectorAggregation {
+		mapped.Connectors = append(mapped.Connectors, EVConnector{
+			Type:                   strings.TrimPrefix(aggregation.Type, "EV_CONNECTOR_TYPE_"),
+			MaxChargeRateKW:        aggregation.MaxChargeRateKw,
+			Count:                  aggregation.Count,
+			AvailableCount:         aggregation.AvailableCount,
+			OutOfServiceCount:      aggregation.OutOfServiceCount,
+			AvailabilityLastUpdate: aggregation.AvailabilityLastUpdateTime,
+		})
+	}
+	return mapped
+}
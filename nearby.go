@@ -8,7 +8,7 @@ import (
 	"strings"
 )
 
-const nearbyFieldMask = "places.id,places.displayName,places.formattedAddress,places.location,places.rating,places.priceLevel,places.types,places.currentOpeningHours"
+var nearbyFieldMask = mustFieldMask(PlacesFields(summaryFields...)...)
 
 // NearbySearch performs a nearby search around a location restriction.
 func (c *Client) NearbySearch(ctx context.Context, req NearbySearchRequest) (NearbySearchResponse, error) {
@@ -33,12 +33,19 @@ func (c *Client) NearbySearch(ctx context.Context, req NearbySearchRequest) (Nea
 	if len(req.ExcludedTypes) > 0 {
 		body["excludedTypes"] = req.ExcludedTypes
 	}
+	if req.PageToken != "" {
+		body["pageToken"] = req.PageToken
+	}
 
 	endpoint, err := c.buildURL("/places:searchNearby", nil)
 	if err != nil {
 		return NearbySearchResponse{}, err
 	}
-	payload, err := c.doRequest(ctx, http.MethodPost, endpoint, body, nearbyFieldMask)
+	fieldMask := nearbyFieldMask
+	if req.IncludeEVChargeOptions {
+		fieldMask += "," + evChargeFieldMask
+	}
+	payload, err := c.doRequest(ctx, http.MethodPost, endpoint, body, fieldMask)
 	if err != nil {
 		return NearbySearchResponse{}, err
 	}
@@ -6,19 +6,37 @@ type searchResponse struct {
 }
 
 type placeItem struct {
-	ID                  string              `json:"id"`
-	DisplayName         *displayNamePayload `json:"displayName,omitempty"`
-	FormattedAddress    string              `json:"formattedAddress,omitempty"`
-	Location            *location           `json:"location,omitempty"`
-	Rating              *float64            `json:"rating,omitempty"`
-	PriceLevel          string              `json:"priceLevel,omitempty"`
-	Types               []string            `json:"types,omitempty"`
-	CurrentOpeningHours *openingHours       `json:"currentOpeningHours,omitempty"`
-	RegularOpeningHours *openingHours       `json:"regularOpeningHours,omitempty"`
-	NationalPhoneNumber string              `json:"nationalPhoneNumber,omitempty"`
-	WebsiteURI          string              `json:"websiteUri,omitempty"`
-	Reviews             []reviewPayload     `json:"reviews,omitempty"`
-	Photos              []photoPayload      `json:"photos,omitempty"`
+	ID                  string                `json:"id"`
+	DisplayName         *displayNamePayload   `json:"displayName,omitempty"`
+	FormattedAddress    string                `json:"formattedAddress,omitempty"`
+	Location            *location             `json:"location,omitempty"`
+	Rating              *float64              `json:"rating,omitempty"`
+	UserRatingCount     *int                  `json:"userRatingCount,omitempty"`
+	PriceLevel          string                `json:"priceLevel,omitempty"`
+	PriceRange          *priceRangePayload    `json:"priceRange,omitempty"`
+	Types               []string              `json:"types,omitempty"`
+	PrimaryType         string                `json:"primaryType,omitempty"`
+	PrimaryTypeName     *displayNamePayload   `json:"primaryTypeDisplayName,omitempty"`
+	EVChargeOptions     *evChargeOptions      `json:"evChargeOptions,omitempty"`
+	CurrentOpeningHours *openingHours         `json:"currentOpeningHours,omitempty"`
+	RegularOpeningHours *openingHours         `json:"regularOpeningHours,omitempty"`
+	NationalPhoneNumber string                `json:"nationalPhoneNumber,omitempty"`
+	WebsiteURI          string                `json:"websiteUri,omitempty"`
+	Reviews             []reviewPayload       `json:"reviews,omitempty"`
+	Photos              []photoPayload        `json:"photos,omitempty"`
+	EditorialSummary    *localizedTextPayload `json:"editorialSummary,omitempty"`
+}
+
+type priceRangePayload struct {
+	StartPrice *moneyPayload `json:"startPrice,omitempty"`
+	EndPrice   *moneyPayload `json:"endPrice,omitempty"`
+}
+
+// moneyPayload is google.type.Money: units is an int64 encoded as a string.
+type moneyPayload struct {
+	CurrencyCode string `json:"currencyCode,omitempty"`
+	Units        string `json:"units,omitempty"`
+	Nanos        int    `json:"nanos,omitempty"`
 }
 
 type displayNamePayload struct {
@@ -71,3 +89,17 @@ type photoPayload struct {
 	HeightPx           int                        `json:"heightPx,omitempty"`
 	AuthorAttributions []authorAttributionPayload `json:"authorAttributions,omitempty"`
 }
+
+type evChargeOptions struct {
+	ConnectorCount       int                    `json:"connectorCount,omitempty"`
+	ConnectorAggregation []connectorAggregation `json:"connectorAggregation,omitempty"`
+}
+
+type connectorAggregation struct {
+	Type                       string  `json:"type,omitempty"`
+	MaxChargeRateKw            float64 `json:"maxChargeRateKw,omitempty"`
+	Count                      int     `json:"count,omitempty"`
+	AvailableCount             *int    `json:"availableCount,omitempty"`
+	OutOfServiceCount          *int    `json:"outOfServiceCount,omitempty"`
+	AvailabilityLastUpdateTime string  `json:"availabilityLastUpdateTime,omitempty"`
+}
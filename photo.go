@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -47,3 +48,123 @@ type photoMediaPayload struct {
 	Name     string `json:"name,omitempty"`
 	PhotoURI string `json:"photoUri,omitempty"`
 }
+
+const (
+	maxPhotoRedirects = 5
+	// maxPhotoBytes caps downloads; Google serves at most 4800px images.
+	maxPhotoBytes = 20 << 20
+)
+
+// PhotoOptions controls FetchPhoto.
+type PhotoOptions struct {
+	// ReturnURL stops at Google's redirect and returns the CDN URL without
+	// downloading the image, for apps that hand the URL to a browser.
+	ReturnURL bool
+}
+
+// PhotoData is a fetched photo. Data is empty when PhotoOptions.ReturnURL is set.
+type PhotoData struct {
+	// URL is where the image is served from (the redirect target).
+	URL         string `json:"url"`
+	ContentType string `json:"content_type,omitempty"`
+	Data        []byte `json:"-"`
+}
+
+// FetchPhoto requests the photo media endpoint and follows its redirect to the
+// image itself. Redirects are followed here rather than by the http.Client, so
+// a custom client that refuses redirects still works, and the API key header
+// is only sent to Google's API host.
+func (c *Client) FetchPhoto(ctx context.Context, req PhotoMediaRequest, opts PhotoOptions) (PhotoData, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return PhotoData{}, ValidationError{Field: "name", Message: "required"}
+	}
+	if strings.TrimSpace(c.apiKey) == "" {
+		return PhotoData{}, ErrMissingAPIKey
+	}
+
+	query := map[string]string{}
+	if req.MaxWidthPx > 0 {
+		query["maxWidthPx"] = strconv.Itoa(req.MaxWidthPx)
+	}
+	if req.MaxHeightPx > 0 {
+		query["maxHeightPx"] = strconv.Itoa(req.MaxHeightPx)
+	}
+	endpoint, err := c.buildURL("/"+strings.TrimPrefix(name, "/")+"/media", query)
+	if err != nil {
+		return PhotoData{}, err
+	}
+
+	noRedirects := *c.httpClient
+	noRedirects.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	// Only the first hop hits the Places API; redirects go to the image host.
+	if err := c.waitForLimiter(ctx); err != nil {
+		return PhotoData{}, err
+	}
+	target := endpoint
+	for hop := 0; ; hop++ {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+		if err != nil {
+			return PhotoData{}, fmt.Errorf("goplaces: build photo request: %w", err)
+		}
+		request.Header.Set("User-Agent", c.userAgent)
+		if hop == 0 {
+			request.Header.Set("X-Goog-Api-Key", c.apiKey)
+		}
+		response, err := c.do(&noRedirects, request)
+		if err != nil {
+			return PhotoData{}, fmt.Errorf("goplaces: photo request failed: %w", err)
+		}
+
+		if location := response.Header.Get("Location"); isRedirect(response.StatusCode) && location != "" {
+			_ = response.Body.Close()
+			next, err := response.Request.URL.Parse(location)
+			if err != nil {
+				return PhotoData{}, fmt.Errorf("goplaces: invalid photo redirect: %w", err)
+			}
+			target = next.String()
+			if opts.ReturnURL {
+				return PhotoData{URL: target}, nil
+			}
+			if hop+1 >= maxPhotoRedirects {
+				return PhotoData{}, fmt.Errorf("goplaces: photo request stopped after %d redirects", maxPhotoRedirects)
+			}
+			continue
+		}
+
+		data, err := readPhotoBody(response)
+		if err != nil {
+			return PhotoData{}, err
+		}
+		return PhotoData{URL: target, ContentType: response.Header.Get("Content-Type"), Data: data}, nil
+	}
+}
+
+func readPhotoBody(response *http.Response) ([]byte, error) {
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	payload, err := io.ReadAll(io.LimitReader(response.Body, maxPhotoBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("goplaces: read photo: %w", err)
+	}
+	if response.StatusCode >= http.StatusBadRequest {
+		return nil, newAPIError(response.StatusCode, payload)
+	}
+	if len(payload) > maxPhotoBytes {
+		return nil, fmt.Errorf("goplaces: photo larger than %d bytes", maxPhotoBytes)
+	}
+	return payload, nil
+}
+
+func isRedirect(status int) bool {
+	switch status {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
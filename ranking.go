@@ -0,0 +1,86 @@
+package goplaces
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+const (
+	defaultScorePrior      = 3.5
+	defaultScoreMinReviews = 25
+)
+
+// ScoreFunc assigns a ranking score to a place. Higher scores rank first.
+type ScoreFunc func(PlaceSummary) float64
+
+// DefaultScore ranks by Bayesian-adjusted rating, so a 5.0 with three reviews
+// does not outrank a 4.7 with three thousand.
+var DefaultScore = BayesianScore(defaultScorePrior, defaultScoreMinReviews)
+
+// BayesianScore returns a ScoreFunc that pulls each rating toward prior,
+// weighted as if the place had minReviews extra reviews at that prior.
+// Places without a rating score as the prior.
+func BayesianScore(prior float64, minReviews int) ScoreFunc {
+	weight := float64(max(minReviews, 0))
+	return func(place PlaceSummary) float64 {
+		if place.Rating == nil {
+			return prior
+		}
+		count := 0.0
+		if place.UserRatingCount != nil && *place.UserRatingCount > 0 {
+			count = float64(*place.UserRatingCount)
+		}
+		if count+weight == 0 {
+			return *place.Rating
+		}
+		return (count*(*place.Rating) + weight*prior) / (count + weight)
+	}
+}
+
+// RankPlaces returns a copy of places sorted by score, highest first.
+// Ties keep their API order. A nil score uses DefaultScore.
+func RankPlaces(places []PlaceSummary, score ScoreFunc) []PlaceSummary {
+	if score == nil {
+		score = DefaultScore
+	}
+	type scoredPlace struct {
+		place PlaceSummary
+		score float64
+	}
+	scored := make([]scoredPlace, len(places))
+	for i, place := range places {
+		scored[i] = scoredPlace{place: place, score: score(place)}
+	}
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+	ranked := make([]PlaceSummary, len(scored))
+	for i, entry := range scored {
+		ranked[i] = entry.place
+	}
+	return ranked
+}
+
+// SearchAll fetches every page of a text search, drops duplicate places, and
+// ranks the merged results by score. A nil score uses DefaultScore.
+func (c *Client) SearchAll(ctx context.Context, req SearchRequest, score ScoreFunc) ([]PlaceSummary, error) {
+	it := c.SearchIterator(req)
+	seen := map[string]bool{}
+	var places []PlaceSummary
+	for {
+		place, err := it.Next(ctx)
+		if errors.Is(err, ErrIteratorDone) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if seen[place.PlaceID] {
+			continue
+		}
+		seen[place.PlaceID] = true
+		places = append(places, place)
+	}
+	return RankPlaces(places, score), nil
+}
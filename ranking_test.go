@@ -0,0 +1,91 @@
+package goplaces
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBayesianScore(t *testing.T) {
+	score := BayesianScore(3.5, 10)
+	rating := 5.0
+	count := 10
+	got := score(PlaceSummary{Rating: &rating, UserRatingCount: &count})
+	if math.Abs(got-4.25) > 1e-9 {
+		t.Fatalf("unexpected score: %v", got)
+	}
+	if got := score(PlaceSummary{}); got != 3.5 {
+		t.Fatalf("expected prior for unrated place, got %v", got)
+	}
+	if got := BayesianScore(3.5, 0)(PlaceSummary{Rating: &rating}); got != 5 {
+		t.Fatalf("expected raw rating without weight, got %v", got)
+	}
+}
+
+func TestRankPlacesDefaultScore(t *testing.T) {
+	perfect, solid := 5.0, 4.7
+	few, many := 3, 3000
+	places := []PlaceSummary{
+		{PlaceID: "few", Rating: &perfect, UserRatingCount: &few},
+		{PlaceID: "unrated"},
+		{PlaceID: "many", Rating: &solid, UserRatingCount: &many},
+	}
+	ranked := RankPlaces(places, nil)
+	if ranked[0].PlaceID != "many" || ranked[1].PlaceID != "few" || ranked[2].PlaceID != "unrated" {
+		t.Fatalf("unexpected order: %#v", ranked)
+	}
+	if places[0].PlaceID != "few" {
+		t.Fatalf("expected input to stay untouched")
+	}
+}
+
+func TestSearchAllMergesAndRanks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["pageToken"] == "page-2" {
+			_, _ = w.Write([]byte(`{"places": [{"id": "a"}, {"id": "c", "rating": 4.9, "userRatingCount": 900}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"places": [{"id": "a", "rating": 4.0, "userRatingCount": 50}, {"id": "b"}], "nextPageToken": "page-2"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	places, err := client.SearchAll(context.Background(), SearchRequest{Query: "coffee"}, nil)
+	if err != nil {
+		t.Fatalf("SearchAll error: %v", err)
+	}
+	if len(places) != 3 {
+		t.Fatalf("expected deduped results, got %#v", places)
+	}
+	if places[0].PlaceID != "c" || places[1].PlaceID != "a" || places[2].PlaceID != "b" {
+		t.Fatalf("unexpected order: %#v", places)
+	}
+
+	byName := func(place PlaceSummary) float64 { return -float64(place.PlaceID[0]) }
+	places, err = client.SearchAll(context.Background(), SearchRequest{Query: "coffee"}, byName)
+	if err != nil {
+		t.Fatalf("SearchAll error: %v", err)
+	}
+	if places[0].PlaceID != "a" {
+		t.Fatalf("expected custom score order, got %#v", places)
+	}
+}
+
+func TestSearchAllError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL})
+	if _, err := client.SearchAll(context.Background(), SearchRequest{Query: "coffee"}, nil); err == nil {
+		t.Fatalf("expected error")
+	}
+}
@@ -0,0 +1,187 @@
+package goplaces
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter paces outbound requests. Wait blocks until a request may be
+// sent, or returns the context's error.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// waitForLimiter blocks on Options.RateLimiter before one HTTP request.
+func (c *Client) waitForLimiter(ctx context.Context) error {
+	if c.limiter == nil {
+		return nil
+	}
+	return c.limiter.Wait(ctx)
+}
+
+// Priority orders requests waiting on a TokenBucket.
+type Priority int
+
+const (
+	// PriorityInteractive is the default: user-facing calls that should not
+	// queue behind background work.
+	PriorityInteractive Priority = iota
+	// PriorityBatch yields to interactive calls. Batch helpers such as
+	// DirectionsBatch use it unless the context already carries a priority.
+	PriorityBatch
+)
+
+type priorityKey struct{}
+
+// WithPriority marks requests made with ctx for a TokenBucket's scheduler.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+func priorityFromContext(ctx context.Context) (Priority, bool) {
+	priority, ok := ctx.Value(priorityKey{}).(Priority)
+	return priority, ok
+}
+
+// withDefaultPriority sets priority unless the caller already chose one.
+func withDefaultPriority(ctx context.Context, priority Priority) context.Context {
+	if _, ok := priorityFromContext(ctx); ok {
+		return ctx
+	}
+	return WithPriority(ctx, priority)
+}
+
+// TokenBucket is a RateLimiter allowing rps requests per second with bursts
+// of up to burst. Waiters are served from two FIFO queues, interactive before
+// batch, so a long batch job cannot starve user-facing calls.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	queues [2][]*tokenWaiter
+	timer  *time.Timer
+	now    func() time.Time
+}
+
+type tokenWaiter struct {
+	ready chan struct{}
+}
+
+// NewRateLimiter returns a TokenBucket that starts full. A burst below 1 is
+// treated as 1; rps <= 0 disables limiting.
+func NewRateLimiter(rps float64, burst int) *TokenBucket {
+	burst = max(burst, 1)
+	return &TokenBucket{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// Wait takes a token, queueing by the context's Priority when none is free.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if b.rate <= 0 {
+		return nil
+	}
+	priority, _ := priorityFromContext(ctx)
+	if priority != PriorityBatch {
+		priority = PriorityInteractive
+	}
+
+	b.mu.Lock()
+	b.refill()
+	if b.tokens >= 1 && b.queuedAhead(priority) == 0 {
+		b.tokens--
+		b.mu.Unlock()
+		return nil
+	}
+	waiter := &tokenWaiter{ready: make(chan struct{})}
+	b.queues[priority] = append(b.queues[priority], waiter)
+	b.schedule()
+	b.mu.Unlock()
+
+	select {
+	case <-waiter.ready:
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if !b.remove(priority, waiter) {
+			// Granted while giving up: hand the token back.
+			b.tokens = min(b.tokens+1, b.burst)
+			b.dispatchLocked()
+		}
+		return ctx.Err()
+	}
+}
+
+// queuedAhead counts waiters that must be served before a new request.
+func (b *TokenBucket) queuedAhead(priority Priority) int {
+	if priority == PriorityInteractive {
+		return len(b.queues[PriorityInteractive])
+	}
+	return len(b.queues[PriorityInteractive]) + len(b.queues[PriorityBatch])
+}
+
+func (b *TokenBucket) refill() {
+	now := b.now()
+	elapsed := now.Sub(b.last).Seconds()
+	b.last = now
+	if elapsed > 0 {
+		b.tokens = min(b.tokens+elapsed*b.rate, b.burst)
+	}
+}
+
+// schedule arms a timer for when the next token is due. Callers hold mu.
+func (b *TokenBucket) schedule() {
+	if b.timer != nil || b.queuedAhead(PriorityBatch) == 0 {
+		return
+	}
+	delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	b.timer = time.AfterFunc(max(delay, 0), b.dispatch)
+}
+
+func (b *TokenBucket) dispatch() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.timer = nil
+	b.dispatchLocked()
+}
+
+// dispatchLocked grants available tokens, interactive queue first.
+func (b *TokenBucket) dispatchLocked() {
+	b.refill()
+	for b.tokens >= 1 {
+		queue := PriorityInteractive
+		if len(b.queues[queue]) == 0 {
+			queue = PriorityBatch
+		}
+		if len(b.queues[queue]) == 0 {
+			break
+		}
+		waiter := b.queues[queue][0]
+		b.queues[queue] = b.queues[queue][1:]
+		b.tokens--
+		close(waiter.ready)
+	}
+	b.schedule()
+}
+
+func (b *TokenBucket) remove(priority Priority, waiter *tokenWaiter) bool {
+	queue := b.queues[priority]
+	for i, queued := range queue {
+		if queued == waiter {
+			b.queues[priority] = append(queue[:i], queue[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
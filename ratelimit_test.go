@@ -0,0 +1,209 @@
+package goplaces
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketBurst(t *testing.T) {
+	bucket := NewRateLimiter(50, 2)
+	start := time.Now()
+	for range 3 {
+		if err := bucket.Wait(context.Background()); err != nil {
+			t.Fatalf("wait: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Fatalf("third call should wait for a token, took %s", elapsed)
+	}
+
+	unlimited := NewRateLimiter(0, 0)
+	for range 100 {
+		if err := unlimited.Wait(context.Background()); err != nil {
+			t.Fatalf("wait: %v", err)
+		}
+	}
+}
+
+func TestTokenBucketServesInteractiveFirst(t *testing.T) {
+	bucket := NewRateLimiter(20, 1)
+	if err := bucket.Wait(context.Background()); err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	enqueue := func(name string, priority Priority, queued int) {
+		wg.Go(func() {
+			if err := bucket.Wait(WithPriority(context.Background(), priority)); err != nil {
+				t.Errorf("%s wait: %v", name, err)
+			}
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+		})
+		waitForQueued(t, bucket, priority, queued)
+	}
+	enqueue("batch-1", PriorityBatch, 1)
+	enqueue("batch-2", PriorityBatch, 2)
+	enqueue("interactive", PriorityInteractive, 1)
+	wg.Wait()
+
+	if len(order) != 3 || order[0] != "interactive" || order[1] != "batch-1" || order[2] != "batch-2" {
+		t.Fatalf("unexpected service order: %v", order)
+	}
+}
+
+func TestTokenBucketCancel(t *testing.T) {
+	bucket := NewRateLimiter(1, 1)
+	if err := bucket.Wait(context.Background()); err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := bucket.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+	bucket.mu.Lock()
+	queued := bucket.queuedAhead(PriorityBatch)
+	bucket.mu.Unlock()
+	if queued != 0 {
+		t.Fatalf("cancelled waiter left in queue")
+	}
+
+	canceled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	if err := NewRateLimiter(1, 1).Wait(canceled); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled error, got %v", err)
+	}
+}
+
+func TestDirectionsBatchUsesBatchPriority(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{"distance": {"value": 1}, "duration": {"value": 1}}]}]}`))
+	}))
+	defer server.Close()
+
+	limiter := &recordingLimiter{}
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL, RateLimiter: limiter})
+	if _, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B"}); err != nil {
+		t.Fatalf("directions: %v", err)
+	}
+	reqs := []DirectionsRequest{{From: "A", To: "B"}, {From: "A", To: "C"}}
+	if _, err := client.DirectionsBatch(context.Background(), reqs, BatchOptions{}); err != nil {
+		t.Fatalf("batch: %v", err)
+	}
+	interactive := WithPriority(context.Background(), PriorityInteractive)
+	if _, err := client.DirectionsBatch(interactive, reqs[:1], BatchOptions{}); err != nil {
+		t.Fatalf("batch: %v", err)
+	}
+
+	want := []Priority{PriorityInteractive, PriorityBatch, PriorityBatch, PriorityInteractive}
+	if len(limiter.priorities) != len(want) {
+		t.Fatalf("expected %d waits, got %v", len(want), limiter.priorities)
+	}
+	for i, priority := range want {
+		if limiter.priorities[i] != priority {
+			t.Fatalf("wait %d: expected priority %d, got %v", i, priority, limiter.priorities)
+		}
+	}
+}
+
+func TestRateLimiterWaitsBeforeEveryRequest(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		switch r.URL.Path {
+		case routesPath:
+			_, _ = w.Write([]byte("{\"routes\": [{\"polyline\": {\"encodedPolyline\": \"_p~iF~ps|U_ulLnnqC_mqNvxq`@\"}}]}"))
+		case "/directions":
+			_, _ = w.Write([]byte(`{"status": "OK", "routes": [{"legs": [{"distance": {"value": 1}, "duration": {"value": 1}}]}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"places": []}`))
+		}
+	}))
+	defer server.Close()
+
+	limiter := &recordingLimiter{}
+	client := NewClient(Options{
+		APIKey:            "test-key",
+		BaseURL:           server.URL,
+		RoutesBaseURL:     server.URL,
+		DirectionsBaseURL: server.URL + "/directions",
+		RateLimiter:       limiter,
+	})
+	ctx := context.Background()
+	calls := []func() error{
+		func() error { _, err := client.Search(ctx, SearchRequest{Query: "coffee"}); return err },
+		func() error {
+			_, err := client.NearbySearch(ctx, NearbySearchRequest{LocationRestriction: &LocationBias{Lat: 1, Lng: 2, RadiusM: 100}})
+			return err
+		},
+		func() error { _, err := client.Directions(ctx, DirectionsRequest{From: "A", To: "B"}); return err },
+		func() error {
+			_, err := client.Route(ctx, RouteRequest{Query: "coffee", From: "A", To: "B"})
+			return err
+		},
+	}
+	for i, call := range calls {
+		before := hits.Load()
+		waits := limiter.count()
+		if err := call(); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+		if sent, waited := hits.Load()-before, limiter.count()-waits; sent == 0 || int(sent) != waited {
+			t.Fatalf("call %d: %d requests but %d waits", i, sent, waited)
+		}
+	}
+
+	limiter.err = context.Canceled
+	before := hits.Load()
+	if _, err := client.Search(ctx, SearchRequest{Query: "coffee"}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the limiter's error, got %v", err)
+	}
+	if hits.Load() != before {
+		t.Fatalf("request sent after Wait failed")
+	}
+}
+
+type recordingLimiter struct {
+	mu         sync.Mutex
+	priorities []Priority
+	err        error
+}
+
+func (l *recordingLimiter) Wait(ctx context.Context) error {
+	priority, _ := priorityFromContext(ctx)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.priorities = append(l.priorities, priority)
+	return l.err
+}
+
+func (l *recordingLimiter) count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.priorities)
+}
+
+func waitForQueued(t *testing.T, bucket *TokenBucket, priority Priority, want int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		bucket.mu.Lock()
+		queued := len(bucket.queues[priority])
+		bucket.mu.Unlock()
+		if queued == want {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("waiter never queued")
+}
@@ -8,7 +8,7 @@ import (
 	"strings"
 )
 
-const resolveFieldMask = "places.id,places.displayName,places.formattedAddress,places.location,places.types"
+var resolveFieldMask = mustFieldMask(PlacesFields(FieldID, FieldName, FieldAddress, FieldLocation, FieldTypes)...)
 
 // Resolve converts a free-form location string into candidate places.
 func (c *Client) Resolve(ctx context.Context, req LocationResolveRequest) (LocationResolveResponse, error) {
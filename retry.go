@@ -0,0 +1,80 @@
+package goplaces
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultRetryBaseDelay is the first backoff when Options.MaxRetries is set
+	// without a RetryBaseDelay; each further attempt doubles it.
+	DefaultRetryBaseDelay = 250 * time.Millisecond
+	maxRetryDelay         = 30 * time.Second
+)
+
+// withRetries calls send until it succeeds, fails permanently, or the client's
+// retry budget is spent. Transient HTTP statuses (429, 500, 502, 503, 504) and
+// legacy OVER_QUERY_LIMIT bodies are retried; everything else returns at once.
+// send must build a fresh request each time.
+func (c *Client) withRetries(ctx context.Context, send func() ([]byte, error)) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		payload, err := send()
+		if attempt >= c.maxRetries || !shouldRetry(payload, err) {
+			return payload, err
+		}
+		if err := sleepContext(ctx, c.retryDelay(attempt)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func shouldRetry(payload []byte, err error) bool {
+	if err != nil {
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			return false
+		}
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	// Legacy APIs report quota exhaustion with HTTP 200 and a status field.
+	if !bytes.Contains(payload, []byte("OVER_QUERY_LIMIT")) {
+		return false
+	}
+	var envelope struct {
+		Status string `json:"status"`
+	}
+	return json.Unmarshal(payload, &envelope) == nil && envelope.Status == "OVER_QUERY_LIMIT"
+}
+
+// retryDelay is exponential with jitter: a random point in the upper half of
+// base * 2^attempt, so clients that failed together do not retry together.
+func (c *Client) retryDelay(attempt int) time.Duration {
+	// Clamp before shifting: a large base overflows long before attempt 32.
+	delay := maxRetryDelay
+	if attempt < 32 && c.retryBaseDelay <= maxRetryDelay>>attempt {
+		delay = c.retryBaseDelay << attempt
+	}
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
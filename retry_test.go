@@ -0,0 +1,99 @@
+package goplaces
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const directionsOKBody = `{"status": "OK", "routes": [{"legs": [{"distance": {"value": 1}, "duration": {"value": 1}}]}]}`
+
+func TestDirectionsRetriesTransientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		switch calls.Add(1) {
+		case 1, 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error_message": "backend unavailable"}`))
+		case 3:
+			_, _ = w.Write([]byte(`{"status": "OVER_QUERY_LIMIT", "routes": []}`))
+		default:
+			_, _ = w.Write([]byte(directionsOKBody))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL, MaxRetries: 3, RetryBaseDelay: time.Millisecond})
+	if _, err := client.Directions(context.Background(), DirectionsRequest{From: "A", To: "B"}); err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if calls.Load() != 4 {
+		t.Fatalf("expected 4 calls, got %d", calls.Load())
+	}
+}
+
+func TestRetriesStopOnPermanentErrorsAndBudget(t *testing.T) {
+	var calls atomic.Int32
+	status := http.StatusBadRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, MaxRetries: 2, RetryBaseDelay: time.Millisecond})
+	if _, err := client.Search(context.Background(), SearchRequest{Query: "coffee"}); err == nil {
+		t.Fatalf("expected error")
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("400 must not be retried, got %d calls", calls.Load())
+	}
+
+	calls.Store(0)
+	status = http.StatusTooManyRequests
+	_, err := client.Search(context.Background(), SearchRequest{Query: "coffee"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected the last 429, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("expected 1 call plus 2 retries, got %d", calls.Load())
+	}
+}
+
+func TestRetryHonorsContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("bad gateway"))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", DirectionsBaseURL: server.URL, MaxRetries: 5, RetryBaseDelay: time.Hour})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.Directions(ctx, DirectionsRequest{From: "A", To: "B"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error during backoff, got %v", err)
+	}
+}
+
+func TestRetryDelayBounds(t *testing.T) {
+	client := NewClient(Options{APIKey: "test-key", MaxRetries: 1})
+	for attempt, want := range []time.Duration{DefaultRetryBaseDelay, 2 * DefaultRetryBaseDelay, 4 * DefaultRetryBaseDelay} {
+		if delay := client.retryDelay(attempt); delay < want/2 || delay > want {
+			t.Fatalf("attempt %d: delay %s outside [%s, %s]", attempt, delay, want/2, want)
+		}
+	}
+	if delay := client.retryDelay(100); delay > maxRetryDelay {
+		t.Fatalf("delay not capped: %s", delay)
+	}
+	large := NewClient(Options{APIKey: "test-key", MaxRetries: 1, RetryBaseDelay: time.Hour})
+	for _, attempt := range []int{0, 10, 31} {
+		if delay := large.retryDelay(attempt); delay < maxRetryDelay/2 || delay > maxRetryDelay {
+			t.Fatalf("attempt %d with a large base: delay %s outside [%s, %s]", attempt, delay, maxRetryDelay/2, maxRetryDelay)
+		}
+	}
+}
@@ -7,7 +7,10 @@ import (
 	"fmt"
 	"math"
 	"net/http"
+	"regexp"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -15,6 +18,14 @@ const (
 	defaultRoutesBaseURL = "https://routes.googleapis.com"
 	routesPath           = "/directions/v2:computeRoutes"
 	routesFieldMask      = "routes.polyline.encodedPolyline"
+	// Extra computations surface their results under travelAdvisory.
+	routesTravelAdvisoryField = "routes.travelAdvisory"
+	// Reference and alternative routes need labels to tell them apart from
+	// the default route.
+	routesReferenceFields = "routes.routeLabels,routes.distanceMeters,routes.duration"
+	routesRouteTokenField = "routes.routeToken"
+	routesStepFields      = "routes.legs.steps.navigationInstruction,routes.legs.steps.distanceMeters," +
+		"routes.legs.steps.staticDuration,routes.legs.steps.localizedValues,routes.legs.steps.travelMode"
 )
 
 const (
@@ -34,6 +45,27 @@ const (
 	travelModeTransit    = "TRANSIT"
 )
 
+// knownExtraComputations are the Routes API values this package has been tested
+// against. Unknown values still pass through so new Google features work early.
+var knownExtraComputations = map[string]struct{}{
+	"TOLLS":                                  {},
+	"FUEL_CONSUMPTION":                       {},
+	"TRAFFIC_ON_POLYLINE":                    {},
+	"HTML_FORMATTED_NAVIGATION_INSTRUCTIONS": {},
+	"FLYOVER_INFO_ON_POLYLINE":               {},
+	"NARROW_ROAD_INFO_ON_POLYLINE":           {},
+}
+
+const (
+	routeLabelDefault        = "DEFAULT_ROUTE"
+	referenceRouteFuel       = "FUEL_EFFICIENT"
+	referenceRouteShorter    = "SHORTER_DISTANCE"
+	routingPreferenceOptimal = "TRAFFIC_AWARE_OPTIMAL"
+	routingPreferenceAware   = "TRAFFIC_AWARE"
+)
+
+var extraComputationPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
 var travelModes = map[string]struct{}{
 	travelModeDrive:      {},
 	travelModeWalk:       {},
@@ -53,11 +85,79 @@ type RouteRequest struct {
 	Limit        int     `json:"limit,omitempty"`
 	Language     string  `json:"language,omitempty"`
 	Region       string  `json:"region,omitempty"`
+	// DetourTime estimates detour minutes per place via extra Directions calls.
+	DetourTime bool `json:"detour_time,omitempty"`
+	// ExtraComputations is passed through as Routes API extraComputations.
+	// Values outside the known set are forwarded with a warning for forward compatibility.
+	ExtraComputations []string `json:"extra_computations,omitempty"`
+	// RequestedReferenceRoutes asks for labeled routes (FUEL_EFFICIENT,
+	// SHORTER_DISTANCE) alongside the default; see RouteResponse.Routes.
+	RequestedReferenceRoutes []string `json:"requested_reference_routes,omitempty"`
+	// Steps requests turn-by-turn instructions for the default route.
+	Steps bool `json:"steps,omitempty"`
+	// IncludeRouteToken requests the Navigation SDK routeToken (DRIVE and
+	// TWO_WHEELER only; switches to traffic-aware routing).
+	IncludeRouteToken bool `json:"include_route_token,omitempty"`
+	// ComputeAlternativeRoutes asks Google for up to three alternatives
+	// (labeled DEFAULT_ROUTE_ALTERNATE) alongside the default; see
+	// RouteResponse.Routes. Places are still searched along the default.
+	ComputeAlternativeRoutes bool `json:"compute_alternative_routes,omitempty"`
+	// PolylineOnly computes the route without the (billed) waypoint searches;
+	// the response has no Waypoints or Places, and Query is not required.
+	PolylineOnly bool `json:"polyline_only,omitempty"`
 }
 
 // RouteResponse contains sampled waypoints with search results.
 type RouteResponse struct {
 	Waypoints []RouteWaypoint `json:"waypoints"`
+	// Places lists unique results across waypoints, closest to the route first.
+	Places []RoutePlace `json:"places,omitempty"`
+	// TravelAdvisory is the raw Routes API travelAdvisory, populated when
+	// ExtraComputations are requested.
+	TravelAdvisory json.RawMessage `json:"travel_advisory,omitempty"`
+	Warnings       []string        `json:"warnings,omitempty"`
+	// Routes is populated when RequestedReferenceRoutes or
+	// ComputeAlternativeRoutes is set: the default route first, then each
+	// reference or alternative route Google returned.
+	Routes []LabeledRoute `json:"routes,omitempty"`
+	// Steps are the default route's instructions (RouteRequest.Steps), in the
+	// same shape as DirectionsResponse.Steps.
+	Steps []DirectionsStep `json:"steps,omitempty"`
+	// RouteToken is the default route's opaque Navigation SDK token, set when
+	// IncludeRouteToken was requested and Google issued one.
+	RouteToken string `json:"route_token,omitempty"`
+	// EncodedPolyline is the default route's overview geometry, the path
+	// waypoints are sampled from; see DecodePolyline.
+	EncodedPolyline string `json:"encoded_polyline,omitempty"`
+}
+
+// LabeledRoute summarizes one computed route and its Routes API labels
+// (e.g. DEFAULT_ROUTE, FUEL_EFFICIENT).
+type LabeledRoute struct {
+	Labels          []string `json:"labels,omitempty"`
+	DistanceMeters  int      `json:"distance_meters"`
+	DurationSeconds int      `json:"duration_seconds"`
+	EncodedPolyline string   `json:"encoded_polyline,omitempty"`
+}
+
+// HasLabel reports whether the route carries label (case-insensitive).
+func (r LabeledRoute) HasLabel(label string) bool {
+	for _, value := range r.Labels {
+		if strings.EqualFold(value, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// RoutePlace annotates a place with how far it sits off the route.
+type RoutePlace struct {
+	PlaceSummary
+	// DistanceFromRouteM is the straight-line distance to the nearest route point.
+	DistanceFromRouteM float64 `json:"distance_from_route_m"`
+	// DetourSeconds is the round trip from the nearest route point
+	// (RouteRequest.DetourTime); nil when that Directions lookup failed.
+	DetourSeconds *int `json:"detour_seconds,omitempty"`
 }
 
 // RouteWaypoint ties a sampled route location to search results.
@@ -73,12 +173,24 @@ func (c *Client) Route(ctx context.Context, req RouteRequest) (RouteResponse, er
 		return RouteResponse{}, err
 	}
 
-	polyline, err := c.computeRoutePolyline(ctx, req)
+	routes, err := c.computeRoutes(ctx, req)
 	if err != nil {
 		return RouteResponse{}, err
 	}
+	route := routes[0]
+	response := RouteResponse{
+		TravelAdvisory:  route.TravelAdvisory,
+		Warnings:        extraComputationWarnings(req.ExtraComputations),
+		Routes:          labeledRoutes(req, routes),
+		Steps:           mapRouteSteps(route),
+		RouteToken:      route.RouteToken,
+		EncodedPolyline: route.Polyline.EncodedPolyline,
+	}
+	if req.PolylineOnly {
+		return response, nil
+	}
 
-	points, err := decodePolyline(polyline)
+	points, err := DecodePolyline(route.Polyline.EncodedPolyline)
 	if err != nil {
 		return RouteResponse{}, err
 	}
@@ -110,7 +222,187 @@ func (c *Client) Route(ctx context.Context, req RouteRequest) (RouteResponse, er
 		})
 	}
 
-	return RouteResponse{Waypoints: results}, nil
+	places, err := c.routePlaces(ctx, req, points, results)
+	if err != nil {
+		return RouteResponse{}, err
+	}
+
+	response.Waypoints = results
+	response.Places = places
+	return response, nil
+}
+
+// mapRouteSteps adapts Routes API steps to DirectionsStep. Maneuvers use the
+// Directions API spelling (TURN_LEFT -> turn-left) so both commands match.
+func mapRouteSteps(route routeItem) []DirectionsStep {
+	var steps []DirectionsStep
+	for _, leg := range route.Legs {
+		for _, step := range leg.Steps {
+			maneuver := step.NavigationInstruction.Maneuver
+			if maneuver == "MANEUVER_UNSPECIFIED" {
+				maneuver = ""
+			}
+			steps = append(steps, DirectionsStep{
+				Instruction:     strings.TrimSpace(step.NavigationInstruction.Instructions),
+				DistanceText:    step.LocalizedValues.Distance.Text,
+				DistanceMeters:  step.DistanceMeters,
+				DurationText:    step.LocalizedValues.StaticDuration.Text,
+				DurationSeconds: parseRoutesDuration(step.StaticDuration),
+				TravelMode:      step.TravelMode,
+				Maneuver:        strings.ReplaceAll(strings.ToLower(maneuver), "_", "-"),
+			})
+		}
+	}
+	return steps
+}
+
+func labeledRoutes(req RouteRequest, routes []routeItem) []LabeledRoute {
+	if len(req.RequestedReferenceRoutes) == 0 && !req.ComputeAlternativeRoutes {
+		return nil
+	}
+	labeled := make([]LabeledRoute, 0, len(routes))
+	for _, route := range routes {
+		labeled = append(labeled, LabeledRoute{
+			Labels:          route.RouteLabels,
+			DistanceMeters:  route.DistanceMeters,
+			DurationSeconds: parseRoutesDuration(route.Duration),
+			EncodedPolyline: route.Polyline.EncodedPolyline,
+		})
+	}
+	return labeled
+}
+
+// parseRoutesDuration reads Routes API durations such as "1234s".
+func parseRoutesDuration(value string) int {
+	seconds, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "s"), 64)
+	if err != nil {
+		return 0
+	}
+	return int(math.Round(seconds))
+}
+
+func extraComputationWarnings(values []string) []string {
+	var warnings []string
+	for _, value := range values {
+		if _, ok := knownExtraComputations[value]; !ok {
+			warnings = append(warnings, fmt.Sprintf("unknown extra computation %s passed through to the Routes API", value))
+		}
+	}
+	return warnings
+}
+
+func (c *Client) routePlaces(ctx context.Context, req RouteRequest, points []LatLng, waypoints []RouteWaypoint) ([]RoutePlace, error) {
+	seen := map[string]struct{}{}
+	places := make([]RoutePlace, 0)
+	nearest := make([]LatLng, 0)
+	for _, waypoint := range waypoints {
+		for _, place := range waypoint.Results {
+			if place.Location == nil {
+				continue
+			}
+			if _, ok := seen[place.PlaceID]; ok {
+				continue
+			}
+			seen[place.PlaceID] = struct{}{}
+			distance, point := nearestOnPolyline(*place.Location, points)
+			places = append(places, RoutePlace{PlaceSummary: place, DistanceFromRouteM: distance})
+			nearest = append(nearest, point)
+		}
+	}
+
+	if req.DetourTime {
+		// A place Directions cannot reach (e.g. ErrNoRoute) keeps a nil
+		// DetourSeconds and sorts after the others; only cancellation aborts.
+		mode := directionsModeForTravelMode(req.Mode)
+		_ = runBatch(ctx, len(places), BatchOptions{}, func(ctx context.Context, i int) error {
+			seconds, err := c.detourSeconds(ctx, req, mode, nearest[i], places[i].PlaceID)
+			if err == nil {
+				places[i].DetourSeconds = &seconds
+			}
+			return err
+		})
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(places, func(i, j int) bool {
+		a, b := places[i], places[j]
+		if (a.DetourSeconds == nil) != (b.DetourSeconds == nil) {
+			// Places whose detour is known come first.
+			return a.DetourSeconds != nil
+		}
+		if a.DetourSeconds != nil && *a.DetourSeconds != *b.DetourSeconds {
+			return *a.DetourSeconds < *b.DetourSeconds
+		}
+		return a.DistanceFromRouteM < b.DistanceFromRouteM
+	})
+	return places, nil
+}
+
+// detourSeconds approximates a stop as an out-and-back trip from the nearest route point.
+func (c *Client) detourSeconds(ctx context.Context, req RouteRequest, mode string, from LatLng, placeID string) (int, error) {
+	response, err := c.Directions(ctx, DirectionsRequest{
+		FromLocation: &from,
+		ToPlaceID:    placeID,
+		Mode:         mode,
+		Language:     req.Language,
+		Region:       req.Region,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return 2 * response.DurationSeconds, nil
+}
+
+func directionsModeForTravelMode(mode string) string {
+	switch mode {
+	case travelModeWalk:
+		return directionsModeWalk
+	case travelModeBicycle:
+		return directionsModeBicycle
+	case travelModeTransit:
+		return directionsModeTransit
+	default:
+		return directionsModeDrive
+	}
+}
+
+// nearestOnPolyline returns the distance from point to the closest spot on the path.
+func nearestOnPolyline(point LatLng, path []LatLng) (float64, LatLng) {
+	if len(path) == 0 {
+		return 0, point
+	}
+	best := path[0]
+	bestDistance := distanceMeters(point, best)
+	for i := 1; i < len(path); i++ {
+		candidate := projectOnSegment(point, path[i-1], path[i])
+		if distance := distanceMeters(point, candidate); distance < bestDistance {
+			best = candidate
+			bestDistance = distance
+		}
+	}
+	return bestDistance, best
+}
+
+// projectOnSegment uses a local equirectangular projection, which is accurate
+// enough for the short segments of an overview polyline.
+func projectOnSegment(point, start, end LatLng) LatLng {
+	scale := math.Cos(start.Lat * math.Pi / 180)
+	dx := (end.Lng - start.Lng) * scale
+	dy := end.Lat - start.Lat
+	lengthSquared := dx*dx + dy*dy
+	if lengthSquared == 0 {
+		return start
+	}
+	px := (point.Lng - start.Lng) * scale
+	py := point.Lat - start.Lat
+	fraction := (px*dx + py*dy) / lengthSquared
+	fraction = math.Max(0, math.Min(1, fraction))
+	return LatLng{
+		Lat: start.Lat + (end.Lat-start.Lat)*fraction,
+		Lng: start.Lng + (end.Lng-start.Lng)*fraction,
+	}
 }
 
 func applyRouteDefaults(req RouteRequest) RouteRequest {
@@ -130,11 +422,25 @@ func applyRouteDefaults(req RouteRequest) RouteRequest {
 	if req.MaxWaypoints == 0 {
 		req.MaxWaypoints = defaultRouteWaypoints
 	}
+	if len(req.ExtraComputations) > 0 {
+		computations := make([]string, len(req.ExtraComputations))
+		for i, value := range req.ExtraComputations {
+			computations[i] = strings.ToUpper(strings.TrimSpace(value))
+		}
+		req.ExtraComputations = computations
+	}
+	if len(req.RequestedReferenceRoutes) > 0 {
+		references := make([]string, len(req.RequestedReferenceRoutes))
+		for i, value := range req.RequestedReferenceRoutes {
+			references[i] = strings.ToUpper(strings.TrimSpace(value))
+		}
+		req.RequestedReferenceRoutes = references
+	}
 	return req
 }
 
 func validateRouteRequest(req RouteRequest) error {
-	if req.Query == "" {
+	if req.Query == "" && !req.PolylineOnly {
 		return ValidationError{Field: "query", Message: "required"}
 	}
 	if req.From == "" {
@@ -155,10 +461,38 @@ func validateRouteRequest(req RouteRequest) error {
 	if _, ok := travelModes[req.Mode]; !ok {
 		return ValidationError{Field: "mode", Message: "must be DRIVE, WALK, BICYCLE, TWO_WHEELER, or TRANSIT"}
 	}
+	for _, value := range req.ExtraComputations {
+		if !extraComputationPattern.MatchString(value) {
+			return ValidationError{Field: "extra_computations", Message: fmt.Sprintf("invalid value %q", value)}
+		}
+	}
+	if req.IncludeRouteToken && req.Mode != travelModeDrive && req.Mode != travelModeTwoWheeler {
+		return ValidationError{Field: "include_route_token", Message: "requires DRIVE or TWO_WHEELER mode"}
+	}
+	return validateReferenceRoutes(req)
+}
+
+func validateReferenceRoutes(req RouteRequest) error {
+	for _, value := range req.RequestedReferenceRoutes {
+		switch value {
+		case referenceRouteFuel:
+			if req.Mode != travelModeDrive {
+				return ValidationError{Field: "requested_reference_routes", Message: "FUEL_EFFICIENT requires DRIVE mode"}
+			}
+		case referenceRouteShorter:
+			if req.Mode != travelModeDrive && req.Mode != travelModeTwoWheeler {
+				return ValidationError{Field: "requested_reference_routes", Message: "SHORTER_DISTANCE requires DRIVE or TWO_WHEELER mode"}
+			}
+		default:
+			return ValidationError{Field: "requested_reference_routes", Message: "must be FUEL_EFFICIENT or SHORTER_DISTANCE"}
+		}
+	}
 	return nil
 }
 
-func (c *Client) computeRoutePolyline(ctx context.Context, req RouteRequest) (string, error) {
+// computeRoutes returns Google's default route first, followed by any
+// requested reference or alternative routes.
+func (c *Client) computeRoutes(ctx context.Context, req RouteRequest) ([]routeItem, error) {
 	body := map[string]any{
 		"origin": map[string]any{
 			"address": req.From,
@@ -176,28 +510,65 @@ func (c *Client) computeRoutePolyline(ctx context.Context, req RouteRequest) (st
 	if req.Region != "" {
 		body["regionCode"] = req.Region
 	}
+	fieldMask := routesFieldMask
+	if len(req.ExtraComputations) > 0 {
+		body["extraComputations"] = req.ExtraComputations
+		fieldMask += "," + routesTravelAdvisoryField
+	}
+	if req.Steps {
+		fieldMask += "," + routesStepFields
+	}
+	if req.IncludeRouteToken {
+		// Google only issues tokens for traffic-aware routes.
+		body["routingPreference"] = routingPreferenceAware
+		fieldMask += "," + routesRouteTokenField
+	}
+	if len(req.RequestedReferenceRoutes) > 0 || req.ComputeAlternativeRoutes {
+		fieldMask += "," + routesReferenceFields
+	}
+	if req.ComputeAlternativeRoutes {
+		body["computeAlternativeRoutes"] = true
+	}
+	if len(req.RequestedReferenceRoutes) > 0 {
+		body["requestedReferenceRoutes"] = req.RequestedReferenceRoutes
+		if slices.Contains(req.RequestedReferenceRoutes, referenceRouteFuel) {
+			// Eco-friendly routing is only offered with traffic-aware optimal routing.
+			body["routingPreference"] = routingPreferenceOptimal
+		}
+	}
 
 	endpoint := c.routesBaseURL + routesPath
-	payload, err := c.doRequest(ctx, http.MethodPost, endpoint, body, routesFieldMask)
+	payload, err := c.doRequest(ctx, http.MethodPost, endpoint, body, fieldMask)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	var response routesResponse
 	if err := json.Unmarshal(payload, &response); err != nil {
-		return "", fmt.Errorf("goplaces: decode route response: %w", err)
+		return nil, fmt.Errorf("goplaces: decode route response: %w", err)
 	}
 	if len(response.Routes) == 0 {
-		return "", errors.New("goplaces: no routes returned")
+		return nil, errors.New("goplaces: no routes returned")
+	}
+	routes := response.Routes
+	for i := range routes {
+		routes[i].Polyline.EncodedPolyline = strings.TrimSpace(routes[i].Polyline.EncodedPolyline)
+	}
+	// Google usually lists the default route first, but labels are authoritative.
+	if index := slices.IndexFunc(routes, func(route routeItem) bool {
+		return slices.Contains(route.RouteLabels, routeLabelDefault)
+	}); index > 0 {
+		routes[0], routes[index] = routes[index], routes[0]
 	}
-	polyline := strings.TrimSpace(response.Routes[0].Polyline.EncodedPolyline)
-	if polyline == "" {
-		return "", errors.New("goplaces: empty route polyline")
+	if routes[0].Polyline.EncodedPolyline == "" {
+		return nil, errors.New("goplaces: empty route polyline")
 	}
-	return polyline, nil
+	return routes, nil
 }
 
-func decodePolyline(encoded string) ([]LatLng, error) {
+// DecodePolyline decodes a Google encoded polyline (precision 1e5), such as
+// DirectionsResponse.EncodedPolyline, into its points.
+func DecodePolyline(encoded string) ([]LatLng, error) {
 	if strings.TrimSpace(encoded) == "" {
 		return nil, errors.New("goplaces: empty polyline")
 	}
@@ -244,6 +615,33 @@ func decodePolyline(encoded string) ([]LatLng, error) {
 	return points, nil
 }
 
+// EncodePolyline is the inverse of DecodePolyline; coordinates are rounded to
+// 1e-5 degrees.
+func EncodePolyline(points []LatLng) string {
+	var builder strings.Builder
+	var prevLat, prevLng int
+	for _, point := range points {
+		lat := int(math.Round(point.Lat * routePolylinePrecision))
+		lng := int(math.Round(point.Lng * routePolylinePrecision))
+		encodePolylineValue(&builder, lat-prevLat)
+		encodePolylineValue(&builder, lng-prevLng)
+		prevLat, prevLng = lat, lng
+	}
+	return builder.String()
+}
+
+func encodePolylineValue(builder *strings.Builder, delta int) {
+	value := delta << 1
+	if delta < 0 {
+		value = ^value
+	}
+	for value >= 0x20 {
+		builder.WriteByte(byte((0x20 | (value & 0x1f)) + 63))
+		value >>= 5
+	}
+	builder.WriteByte(byte(value + 63))
+}
+
 func sampleWaypoints(points []LatLng, maxWaypoints int) []LatLng {
 	if len(points) == 0 || maxWaypoints <= 0 {
 		return nil
@@ -362,7 +760,35 @@ type routesResponse struct {
 }
 
 type routeItem struct {
-	Polyline routePolyline `json:"polyline"`
+	Polyline       routePolyline   `json:"polyline"`
+	TravelAdvisory json.RawMessage `json:"travelAdvisory,omitempty"`
+	RouteLabels    []string        `json:"routeLabels,omitempty"`
+	DistanceMeters int             `json:"distanceMeters,omitempty"`
+	Duration       string          `json:"duration,omitempty"`
+	Legs           []routeLeg      `json:"legs,omitempty"`
+	RouteToken     string          `json:"routeToken,omitempty"`
+}
+
+type routeLeg struct {
+	Steps []routeStep `json:"steps,omitempty"`
+}
+
+type routeStep struct {
+	DistanceMeters        int                        `json:"distanceMeters,omitempty"`
+	StaticDuration        string                     `json:"staticDuration,omitempty"`
+	TravelMode            string                     `json:"travelMode,omitempty"`
+	NavigationInstruction routeNavigationInstruction `json:"navigationInstruction"`
+	LocalizedValues       routeStepLocalizedValues   `json:"localizedValues"`
+}
+
+type routeNavigationInstruction struct {
+	Maneuver     string `json:"maneuver,omitempty"`
+	Instructions string `json:"instructions,omitempty"`
+}
+
+type routeStepLocalizedValues struct {
+	Distance       localizedTextPayload `json:"distance"`
+	StaticDuration localizedTextPayload `json:"staticDuration"`
 }
 
 type routePolyline struct {
@@ -3,12 +3,15 @@ package goplaces
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
-func TestComputeRoutePolyline(t *testing.T) {
+func TestComputeRoute(t *testing.T) {
 	var gotBody map[string]any
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != routesPath {
@@ -25,15 +28,15 @@ func TestComputeRoutePolyline(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient(Options{APIKey: "test-key", RoutesBaseURL: server.URL})
-	polyline, err := client.computeRoutePolyline(context.Background(), RouteRequest{
+	routes, err := client.computeRoutes(context.Background(), RouteRequest{
 		From: "Seattle",
 		To:   "Portland",
 		Mode: travelModeDrive,
 	})
 	if err != nil {
-		t.Fatalf("computeRoutePolyline error: %v", err)
+		t.Fatalf("computeRoute error: %v", err)
 	}
-	if polyline == "" {
+	if routes[0].Polyline.EncodedPolyline == "" {
 		t.Fatalf("expected polyline")
 	}
 	if gotBody["travelMode"] != travelModeDrive {
@@ -42,9 +45,9 @@ func TestComputeRoutePolyline(t *testing.T) {
 }
 
 func TestDecodePolyline(t *testing.T) {
-	points, err := decodePolyline("_p~iF~ps|U_ulLnnqC_mqNvxq`@")
+	points, err := DecodePolyline("_p~iF~ps|U_ulLnnqC_mqNvxq`@")
 	if err != nil {
-		t.Fatalf("decodePolyline error: %v", err)
+		t.Fatalf("DecodePolyline error: %v", err)
 	}
 	if len(points) != 3 {
 		t.Fatalf("expected 3 points, got %d", len(points))
@@ -52,17 +55,23 @@ func TestDecodePolyline(t *testing.T) {
 	if points[0].Lat != 38.5 || points[0].Lng != -120.2 {
 		t.Fatalf("unexpected first point: %#v", points[0])
 	}
+	if points[2].Lat != 43.252 || points[2].Lng != -126.453 {
+		t.Fatalf("unexpected last point: %#v", points[2])
+	}
+	if encoded := EncodePolyline(points); encoded != "_p~iF~ps|U_ulLnnqC_mqNvxq`@" {
+		t.Fatalf("round trip mismatch: %q", encoded)
+	}
 }
 
 func TestDecodePolylineInvalid(t *testing.T) {
-	_, err := decodePolyline("")
+	_, err := DecodePolyline("")
 	if err == nil {
 		t.Fatalf("expected decode error")
 	}
 }
 
 func TestDecodePolylineMalformed(t *testing.T) {
-	_, err := decodePolyline("abc")
+	_, err := DecodePolyline("abc")
 	if err == nil {
 		t.Fatalf("expected malformed error")
 	}
@@ -202,6 +211,19 @@ func TestApplyRouteDefaults(t *testing.T) {
 	}
 }
 
+func TestApplyRouteDefaultsKeepsCallerSlices(t *testing.T) {
+	computations := []string{" tolls "}
+	req := applyRouteDefaults(RouteRequest{ExtraComputations: computations})
+	if req.ExtraComputations[0] != "TOLLS" || computations[0] != " tolls " {
+		t.Fatalf("expected a normalized copy, got %q (caller %q)", req.ExtraComputations, computations)
+	}
+	references := []string{"fuel_efficient"}
+	req = applyRouteDefaults(RouteRequest{RequestedReferenceRoutes: references})
+	if req.RequestedReferenceRoutes[0] != referenceRouteFuel || references[0] != "fuel_efficient" {
+		t.Fatalf("expected a normalized copy, got %q (caller %q)", req.RequestedReferenceRoutes, references)
+	}
+}
+
 func TestApplyRouteDefaultsEmpty(t *testing.T) {
 	req := applyRouteDefaults(RouteRequest{})
 	if req.Mode != travelModeDrive {
@@ -218,40 +240,40 @@ func TestApplyRouteDefaultsEmpty(t *testing.T) {
 	}
 }
 
-func TestComputeRoutePolylineErrors(t *testing.T) {
+func TestComputeRouteErrors(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		_, _ = w.Write([]byte(`{"routes":[]}`))
 	}))
 	defer server.Close()
 
 	client := NewClient(Options{APIKey: "test-key", RoutesBaseURL: server.URL})
-	_, err := client.computeRoutePolyline(context.Background(), RouteRequest{From: "A", To: "B"})
+	_, err := client.computeRoutes(context.Background(), RouteRequest{From: "A", To: "B"})
 	if err == nil {
 		t.Fatalf("expected route error")
 	}
 }
 
-func TestComputeRoutePolylineEmpty(t *testing.T) {
+func TestComputeRouteEmpty(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		_, _ = w.Write([]byte(`{"routes":[{"polyline":{"encodedPolyline":""}}]}`))
 	}))
 	defer server.Close()
 
 	client := NewClient(Options{APIKey: "test-key", RoutesBaseURL: server.URL})
-	_, err := client.computeRoutePolyline(context.Background(), RouteRequest{From: "A", To: "B"})
+	_, err := client.computeRoutes(context.Background(), RouteRequest{From: "A", To: "B"})
 	if err == nil {
 		t.Fatalf("expected empty polyline error")
 	}
 }
 
-func TestComputeRoutePolylineInvalidJSON(t *testing.T) {
+func TestComputeRouteInvalidJSON(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		_, _ = w.Write([]byte("not-json"))
 	}))
 	defer server.Close()
 
 	client := NewClient(Options{APIKey: "test-key", RoutesBaseURL: server.URL})
-	_, err := client.computeRoutePolyline(context.Background(), RouteRequest{From: "A", To: "B"})
+	_, err := client.computeRoutes(context.Background(), RouteRequest{From: "A", To: "B"})
 	if err == nil {
 		t.Fatalf("expected json error")
 	}
@@ -287,6 +309,28 @@ func TestRouteEndToEnd(t *testing.T) {
 	if searchCalls == 0 {
 		t.Fatalf("expected search calls")
 	}
+	if response.EncodedPolyline != "_p~iF~ps|U_ulLnnqC_mqNvxq`@" {
+		t.Fatalf("unexpected polyline: %q", response.EncodedPolyline)
+	}
+}
+
+func TestRoutePolylineOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != routesPath {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"routes": [{"polyline": {"encodedPolyline": "_p~iF~ps|U"}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, RoutesBaseURL: server.URL})
+	response, err := client.Route(context.Background(), RouteRequest{From: "A", To: "B", PolylineOnly: true})
+	if err != nil {
+		t.Fatalf("route error: %v", err)
+	}
+	if response.EncodedPolyline != "_p~iF~ps|U" || len(response.Waypoints) != 0 {
+		t.Fatalf("unexpected response: %#v", response)
+	}
 }
 
 func TestRouteSearchError(t *testing.T) {
@@ -333,3 +377,407 @@ func TestRouteComputeRouteError(t *testing.T) {
 		t.Fatalf("expected route error")
 	}
 }
+
+func TestNearestOnPolyline(t *testing.T) {
+	path := []LatLng{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 1}}
+	distance, point := nearestOnPolyline(LatLng{Lat: 0.01, Lng: 0.5}, path)
+	if math.Abs(point.Lng-0.5) > 1e-9 || point.Lat != 0 {
+		t.Fatalf("unexpected nearest point: %#v", point)
+	}
+	if math.Abs(distance-1112) > 5 {
+		t.Fatalf("unexpected distance: %f", distance)
+	}
+
+	distance, point = nearestOnPolyline(LatLng{Lat: 0, Lng: -1}, path)
+	if point != path[0] || distance < 100000 {
+		t.Fatalf("expected clamp to start, got %#v (%f)", point, distance)
+	}
+
+	if distance, _ := nearestOnPolyline(LatLng{Lat: 1, Lng: 1}, nil); distance != 0 {
+		t.Fatalf("expected zero distance for empty path")
+	}
+	if got := projectOnSegment(LatLng{Lat: 1, Lng: 1}, path[0], path[0]); got != path[0] {
+		t.Fatalf("expected degenerate segment start, got %#v", got)
+	}
+}
+
+func TestRoutePlacesSortedByDetour(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case routesPath:
+			_, _ = w.Write([]byte("{\"routes\": [{\"polyline\": {\"encodedPolyline\": \"_p~iF~ps|U_ulLnnqC_mqNvxq`@\"}}]}"))
+		case "/places:searchText":
+			_, _ = w.Write([]byte(`{"places":[
+				{"id":"far","location":{"latitude":39.0,"longitude":-120.2}},
+				{"id":"near","location":{"latitude":38.5,"longitude":-120.21}},
+				{"id":"nowhere"}
+			]}`))
+		case "/directions":
+			if r.URL.Query().Get("mode") != directionsModeWalk {
+				t.Fatalf("unexpected mode: %s", r.URL.Query().Get("mode"))
+			}
+			duration := "900"
+			if r.URL.Query().Get("destination") == "place_id:near" {
+				duration = "60"
+			}
+			_, _ = w.Write([]byte(`{"status":"OK","routes":[{"legs":[{"duration":{"value":` + duration + `},"steps":[]}]}]}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{
+		APIKey:            "test-key",
+		BaseURL:           server.URL,
+		RoutesBaseURL:     server.URL,
+		DirectionsBaseURL: server.URL + "/directions",
+	})
+	response, err := client.Route(context.Background(), RouteRequest{
+		Query:        "coffee",
+		From:         "A",
+		To:           "B",
+		Mode:         travelModeWalk,
+		MaxWaypoints: 2,
+		DetourTime:   true,
+	})
+	if err != nil {
+		t.Fatalf("route error: %v", err)
+	}
+	if len(response.Places) != 2 {
+		t.Fatalf("expected 2 unique located places, got %#v", response.Places)
+	}
+	if response.Places[0].PlaceID != "near" || response.Places[1].PlaceID != "far" {
+		t.Fatalf("unexpected order: %s, %s", response.Places[0].PlaceID, response.Places[1].PlaceID)
+	}
+	if response.Places[0].DetourSeconds == nil || *response.Places[0].DetourSeconds != 120 {
+		t.Fatalf("unexpected detour: %#v", response.Places[0].DetourSeconds)
+	}
+}
+
+func TestDirectionsModeForTravelMode(t *testing.T) {
+	cases := map[string]string{
+		travelModeDrive:      directionsModeDrive,
+		travelModeTwoWheeler: directionsModeDrive,
+		travelModeWalk:       directionsModeWalk,
+		travelModeBicycle:    directionsModeBicycle,
+		travelModeTransit:    directionsModeTransit,
+	}
+	for input, want := range cases {
+		if got := directionsModeForTravelMode(input); got != want {
+			t.Fatalf("%s: expected %s, got %s", input, want, got)
+		}
+	}
+}
+
+func TestRouteDetourFailureKeepsPlace(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case routesPath:
+			_, _ = w.Write([]byte("{\"routes\": [{\"polyline\": {\"encodedPolyline\": \"_p~iF~ps|U_ulLnnqC_mqNvxq`@\"}}]}"))
+		case "/places:searchText":
+			_, _ = w.Write([]byte(`{"places":[
+				{"id":"island","location":{"latitude":38.5,"longitude":-120.21}},
+				{"id":"far","location":{"latitude":39.0,"longitude":-120.2}}
+			]}`))
+		case "/directions":
+			if r.URL.Query().Get("destination") == "place_id:island" {
+				_, _ = w.Write([]byte(`{"status":"ZERO_RESULTS","routes":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":"OK","routes":[{"legs":[{"duration":{"value":300},"steps":[]}]}]}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{
+		APIKey:            "test-key",
+		BaseURL:           server.URL,
+		RoutesBaseURL:     server.URL,
+		DirectionsBaseURL: server.URL + "/directions",
+	})
+	response, err := client.Route(context.Background(), RouteRequest{
+		Query:        "coffee",
+		From:         "A",
+		To:           "B",
+		MaxWaypoints: 1,
+		DetourTime:   true,
+	})
+	if err != nil {
+		t.Fatalf("route error: %v", err)
+	}
+	if len(response.Places) != 2 || response.Places[0].PlaceID != "far" || response.Places[1].PlaceID != "island" {
+		t.Fatalf("expected the reachable place first, got %#v", response.Places)
+	}
+	if response.Places[0].DetourSeconds == nil || *response.Places[0].DetourSeconds != 600 {
+		t.Fatalf("unexpected detour: %#v", response.Places[0].DetourSeconds)
+	}
+	if response.Places[1].DetourSeconds != nil {
+		t.Fatalf("expected no detour for the unreachable place, got %d", *response.Places[1].DetourSeconds)
+	}
+}
+
+func TestRouteExtraComputationsPassthrough(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case routesPath:
+			if !strings.Contains(r.Header.Get("X-Goog-FieldMask"), routesTravelAdvisoryField) {
+				t.Fatalf("expected travel advisory in field mask: %s", r.Header.Get("X-Goog-FieldMask"))
+			}
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			extra, ok := body["extraComputations"].([]any)
+			if !ok || len(extra) != 2 || extra[0] != "TOLLS" || extra[1] != "HEALTHY_AIR_ROUTES" {
+				t.Fatalf("unexpected extraComputations: %#v", body["extraComputations"])
+			}
+			_, _ = w.Write([]byte("{\"routes\": [{\"polyline\": {\"encodedPolyline\": \"_p~iF~ps|U_ulLnnqC_mqNvxq`@\"}, \"travelAdvisory\": {\"tollInfo\": {}}}]}"))
+		case "/places:searchText":
+			_, _ = w.Write([]byte(`{"places":[]}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, RoutesBaseURL: server.URL})
+	response, err := client.Route(context.Background(), RouteRequest{
+		Query:             "coffee",
+		From:              "A",
+		To:                "B",
+		MaxWaypoints:      1,
+		ExtraComputations: []string{"tolls", "HEALTHY_AIR_ROUTES"},
+	})
+	if err != nil {
+		t.Fatalf("route error: %v", err)
+	}
+	if !strings.Contains(string(response.TravelAdvisory), "tollInfo") {
+		t.Fatalf("expected travel advisory, got %s", response.TravelAdvisory)
+	}
+	if len(response.Warnings) != 1 || !strings.Contains(response.Warnings[0], "HEALTHY_AIR_ROUTES") {
+		t.Fatalf("expected unknown computation warning, got %#v", response.Warnings)
+	}
+}
+
+func TestRouteReferenceRoutes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case routesPath:
+			if !strings.Contains(r.Header.Get("X-Goog-FieldMask"), "routes.routeLabels") {
+				t.Fatalf("expected route labels in field mask: %s", r.Header.Get("X-Goog-FieldMask"))
+			}
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			refs, ok := body["requestedReferenceRoutes"].([]any)
+			if !ok || len(refs) != 1 || refs[0] != referenceRouteFuel {
+				t.Fatalf("unexpected requestedReferenceRoutes: %#v", body["requestedReferenceRoutes"])
+			}
+			if body["routingPreference"] != routingPreferenceOptimal {
+				t.Fatalf("unexpected routingPreference: %#v", body["routingPreference"])
+			}
+			_, _ = w.Write([]byte(`{"routes": [
+				{"routeLabels": ["FUEL_EFFICIENT"], "distanceMeters": 11500, "duration": "1020s", "polyline": {"encodedPolyline": "_p~iF~ps|U"}},
+				{"routeLabels": ["DEFAULT_ROUTE"], "distanceMeters": 12000, "duration": "900s", "polyline": {"encodedPolyline": "_p~iF~ps|U_ulLnnqC_mqNvxq` + "`" + `@"}}
+			]}`))
+		case "/places:searchText":
+			_, _ = w.Write([]byte(`{"places":[]}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, RoutesBaseURL: server.URL})
+	response, err := client.Route(context.Background(), RouteRequest{
+		Query:                    "coffee",
+		From:                     "A",
+		To:                       "B",
+		MaxWaypoints:             1,
+		RequestedReferenceRoutes: []string{"fuel_efficient"},
+	})
+	if err != nil {
+		t.Fatalf("route error: %v", err)
+	}
+	if len(response.Routes) != 2 {
+		t.Fatalf("expected 2 routes, got %#v", response.Routes)
+	}
+	if !response.Routes[0].HasLabel("default_route") || response.Routes[0].DurationSeconds != 900 {
+		t.Fatalf("expected default route first, got %#v", response.Routes[0])
+	}
+	if !response.Routes[1].HasLabel(referenceRouteFuel) || response.Routes[1].DistanceMeters != 11500 {
+		t.Fatalf("unexpected reference route: %#v", response.Routes[1])
+	}
+}
+
+func TestRouteAlternatives(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case routesPath:
+			if !strings.Contains(r.Header.Get("X-Goog-FieldMask"), "routes.routeLabels") {
+				t.Fatalf("expected route labels in field mask: %s", r.Header.Get("X-Goog-FieldMask"))
+			}
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body["computeAlternativeRoutes"] != true {
+				t.Fatalf("unexpected computeAlternativeRoutes: %#v", body["computeAlternativeRoutes"])
+			}
+			if _, ok := body["routingPreference"]; ok {
+				t.Fatalf("alternatives should not change routingPreference: %#v", body["routingPreference"])
+			}
+			_, _ = w.Write([]byte(`{"routes": [
+				{"routeLabels": ["DEFAULT_ROUTE"], "distanceMeters": 12000, "duration": "900s", "polyline": {"encodedPolyline": "_p~iF~ps|U"}},
+				{"routeLabels": ["DEFAULT_ROUTE_ALTERNATE"], "distanceMeters": 13100, "duration": "960s", "polyline": {"encodedPolyline": "_ulLnnqC"}}
+			]}`))
+		case "/places:searchText":
+			_, _ = w.Write([]byte(`{"places":[]}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, RoutesBaseURL: server.URL})
+	response, err := client.Route(context.Background(), RouteRequest{
+		Query:                    "coffee",
+		From:                     "A",
+		To:                       "B",
+		MaxWaypoints:             1,
+		ComputeAlternativeRoutes: true,
+	})
+	if err != nil {
+		t.Fatalf("route error: %v", err)
+	}
+	if len(response.Routes) != 2 || !response.Routes[0].HasLabel(routeLabelDefault) {
+		t.Fatalf("expected default route first, got %#v", response.Routes)
+	}
+	if alternate := response.Routes[1]; !alternate.HasLabel("DEFAULT_ROUTE_ALTERNATE") ||
+		alternate.DistanceMeters != 13100 || alternate.EncodedPolyline != "_ulLnnqC" {
+		t.Fatalf("unexpected alternative route: %#v", alternate)
+	}
+}
+
+func TestRouteSteps(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case routesPath:
+			if !strings.Contains(r.Header.Get("X-Goog-FieldMask"), "routes.legs.steps.navigationInstruction") {
+				t.Fatalf("expected step fields in field mask: %s", r.Header.Get("X-Goog-FieldMask"))
+			}
+			_, _ = w.Write([]byte(`{"routes": [{"polyline": {"encodedPolyline": "_p~iF~ps|U"}, "legs": [{"steps": [
+				{"distanceMeters": 120, "staticDuration": "30s", "travelMode": "DRIVE",
+				 "navigationInstruction": {"maneuver": "TURN_LEFT", "instructions": "Turn left onto Pine St"},
+				 "localizedValues": {"distance": {"text": "0.1 km"}, "staticDuration": {"text": "1 min"}}},
+				{"distanceMeters": 5, "navigationInstruction": {"maneuver": "MANEUVER_UNSPECIFIED"}}
+			]}]}]}`))
+		case "/places:searchText":
+			_, _ = w.Write([]byte(`{"places":[]}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, RoutesBaseURL: server.URL})
+	response, err := client.Route(context.Background(), RouteRequest{Query: "coffee", From: "A", To: "B", MaxWaypoints: 1, Steps: true})
+	if err != nil {
+		t.Fatalf("route error: %v", err)
+	}
+	if len(response.Steps) != 2 {
+		t.Fatalf("expected 2 steps, got %#v", response.Steps)
+	}
+	want := DirectionsStep{
+		Instruction:     "Turn left onto Pine St",
+		DistanceText:    "0.1 km",
+		DistanceMeters:  120,
+		DurationText:    "1 min",
+		DurationSeconds: 30,
+		TravelMode:      "DRIVE",
+		Maneuver:        "turn-left",
+	}
+	if response.Steps[0] != want {
+		t.Fatalf("unexpected first step: %#v", response.Steps[0])
+	}
+	if response.Steps[1].Maneuver != "" {
+		t.Fatalf("expected unspecified maneuver to be dropped: %#v", response.Steps[1])
+	}
+}
+
+func TestRouteToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case routesPath:
+			if !strings.Contains(r.Header.Get("X-Goog-FieldMask"), routesRouteTokenField) {
+				t.Fatalf("expected route token in field mask: %s", r.Header.Get("X-Goog-FieldMask"))
+			}
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body["routingPreference"] != routingPreferenceAware {
+				t.Fatalf("unexpected routingPreference: %#v", body["routingPreference"])
+			}
+			_, _ = w.Write([]byte(`{"routes": [{"polyline": {"encodedPolyline": "_p~iF~ps|U"}, "routeToken": "CqMBCjoKMgoU"}]}`))
+		case "/places:searchText":
+			_, _ = w.Write([]byte(`{"places":[]}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", BaseURL: server.URL, RoutesBaseURL: server.URL})
+	response, err := client.Route(context.Background(), RouteRequest{Query: "coffee", From: "A", To: "B", MaxWaypoints: 1, IncludeRouteToken: true})
+	if err != nil {
+		t.Fatalf("route error: %v", err)
+	}
+	if response.RouteToken != "CqMBCjoKMgoU" {
+		t.Fatalf("unexpected route token: %q", response.RouteToken)
+	}
+
+	req := applyRouteDefaults(RouteRequest{Query: "q", From: "A", To: "B", Mode: "walk", IncludeRouteToken: true})
+	if err := validateRouteRequest(req); err == nil {
+		t.Fatalf("expected route token mode validation error")
+	}
+}
+
+func TestValidateRouteRequestReferenceRoutes(t *testing.T) {
+	tests := []RouteRequest{
+		{Query: "q", From: "A", To: "B", RequestedReferenceRoutes: []string{"ALL"}},
+		{Query: "q", From: "A", To: "B", Mode: travelModeWalk, RequestedReferenceRoutes: []string{referenceRouteFuel}},
+		{Query: "q", From: "A", To: "B", Mode: travelModeBicycle, RequestedReferenceRoutes: []string{referenceRouteShorter}},
+	}
+	for _, req := range tests {
+		err := validateRouteRequest(applyRouteDefaults(req))
+		var validation ValidationError
+		if !errors.As(err, &validation) || validation.Field != "requested_reference_routes" {
+			t.Fatalf("expected reference route validation error for %#v, got %v", req.RequestedReferenceRoutes, err)
+		}
+	}
+	ok := applyRouteDefaults(RouteRequest{Query: "q", From: "A", To: "B", Mode: travelModeTwoWheeler, RequestedReferenceRoutes: []string{"shorter_distance"}})
+	if err := validateRouteRequest(ok); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestParseRoutesDuration(t *testing.T) {
+	if got := parseRoutesDuration("165.5s"); got != 166 {
+		t.Fatalf("unexpected duration: %d", got)
+	}
+	if got := parseRoutesDuration("bad"); got != 0 {
+		t.Fatalf("expected 0 for invalid duration, got %d", got)
+	}
+}
+
+func TestValidateRouteRequestExtraComputations(t *testing.T) {
+	req := applyRouteDefaults(RouteRequest{Query: "q", From: "A", To: "B", ExtraComputations: []string{"bad value"}})
+	if err := validateRouteRequest(req); err == nil {
+		t.Fatalf("expected extra computations validation error")
+	}
+}
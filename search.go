@@ -8,7 +8,26 @@ import (
 	"strings"
 )
 
-const searchFieldMask = "places.id,places.displayName,places.formattedAddress,places.location,places.rating,places.priceLevel,places.types,places.currentOpeningHours,nextPageToken"
+// summaryFields are the place fields mapped into PlaceSummary.
+var summaryFields = []string{
+	FieldID,
+	FieldName,
+	FieldAddress,
+	FieldLocation,
+	FieldRating,
+	FieldUserRatingCount,
+	FieldPriceLevel,
+	FieldPriceRange,
+	FieldTypes,
+	FieldCurrentOpeningHours,
+	FieldPrimaryType,
+	FieldPrimaryTypeName,
+}
+
+var searchFieldMask = mustFieldMask(append(PlacesFields(summaryFields...), "nextPageToken")...)
+
+// evChargeFieldMask is opt-in: EV data is billed at a higher SKU.
+var evChargeFieldMask = mustFieldMask(PlacesFields(FieldEVChargeOptions)...)
 
 // Search performs a text search with optional filters.
 func (c *Client) Search(ctx context.Context, req SearchRequest) (SearchResponse, error) {
@@ -22,7 +41,11 @@ func (c *Client) Search(ctx context.Context, req SearchRequest) (SearchResponse,
 	if err != nil {
 		return SearchResponse{}, err
 	}
-	payload, err := c.doRequest(ctx, http.MethodPost, endpoint, body, searchFieldMask)
+	fieldMask := searchFieldMask
+	if req.IncludeEVChargeOptions {
+		fieldMask += "," + evChargeFieldMask
+	}
+	payload, err := c.doRequest(ctx, http.MethodPost, endpoint, body, fieldMask)
 	if err != nil {
 		return SearchResponse{}, err
 	}
@@ -100,14 +123,19 @@ func buildSearchBody(req SearchRequest) map[string]any {
 
 func mapPlaceSummary(place placeItem) PlaceSummary {
 	return PlaceSummary{
-		PlaceID:    place.ID,
-		Name:       displayName(place.DisplayName),
-		Address:    place.FormattedAddress,
-		Location:   mapLatLng(place.Location),
-		Rating:     place.Rating,
-		PriceLevel: mapPriceLevel(place.PriceLevel),
-		Types:      place.Types,
-		OpenNow:    openNow(place.CurrentOpeningHours),
+		PlaceID:         place.ID,
+		Name:            displayName(place.DisplayName),
+		Address:         place.FormattedAddress,
+		Location:        mapLatLng(place.Location),
+		Rating:          place.Rating,
+		UserRatingCount: place.UserRatingCount,
+		PriceLevel:      mapPriceLevel(place.PriceLevel),
+		PriceRange:      mapPriceRange(place.PriceRange),
+		Types:           place.Types,
+		OpenNow:         openNow(place.CurrentOpeningHours),
+		PrimaryType:     place.PrimaryType,
+		PrimaryTypeName: displayName(place.PrimaryTypeName),
+		EVChargeOptions: mapEVChargeOptions(place.EVChargeOptions),
 	}
 }
 
@@ -0,0 +1,97 @@
+package goplaces
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultStaticMapBaseURL is the Google Static Maps API endpoint.
+	DefaultStaticMapBaseURL = "https://maps.googleapis.com/maps/api/staticmap"
+	defaultStaticMapSizePx  = 640
+	staticMapPathStyle      = "weight:5|color:0x4285F4ff"
+)
+
+// StaticMapOptions describes a Static Maps image of a route. The map zooms to
+// fit the path and markers, so no center or zoom is needed.
+type StaticMapOptions struct {
+	// Polyline is an encoded path, usually DirectionsResponse.EncodedPolyline.
+	Polyline    string
+	Origin      *LatLng
+	Destination *LatLng
+	// Waypoints get numbered markers (1-9, then unlabeled).
+	Waypoints []LatLng
+	// Width and Height are in pixels (default 640, the free-tier maximum).
+	Width  int
+	Height int
+	// Scale 2 doubles the pixel density for high-DPI screens.
+	Scale int
+	// The API key is left out unless SignWithKey is set, so URLs can be
+	// logged or shared and the key added by whoever embeds them.
+	APIKey      string
+	SignWithKey bool
+	// BaseURL overrides DefaultStaticMapBaseURL.
+	BaseURL string
+}
+
+// StaticMapOptions fills the path and the origin, waypoint, and destination
+// markers from the route; size, scale, and key are left to the caller.
+func (r DirectionsResponse) StaticMapOptions() StaticMapOptions {
+	opts := StaticMapOptions{Polyline: r.EncodedPolyline}
+	if len(r.Legs) == 0 {
+		return opts
+	}
+	opts.Origin = r.Legs[0].StartLocation
+	opts.Destination = r.Legs[len(r.Legs)-1].EndLocation
+	for _, leg := range r.Legs[1:] {
+		if leg.StartLocation != nil {
+			opts.Waypoints = append(opts.Waypoints, *leg.StartLocation)
+		}
+	}
+	return opts
+}
+
+// StaticMapURL builds a Google Static Maps API URL drawing the route with
+// A (origin) and B (destination) markers.
+func StaticMapURL(opts StaticMapOptions) string {
+	base := strings.TrimSpace(opts.BaseURL)
+	if base == "" {
+		base = DefaultStaticMapBaseURL
+	}
+	width := opts.Width
+	if width <= 0 {
+		width = defaultStaticMapSizePx
+	}
+	height := opts.Height
+	if height <= 0 {
+		height = defaultStaticMapSizePx
+	}
+
+	query := url.Values{}
+	query.Set("size", fmt.Sprintf("%dx%d", width, height))
+	if opts.Scale > 1 {
+		query.Set("scale", strconv.Itoa(opts.Scale))
+	}
+	if opts.Polyline != "" {
+		query.Add("path", staticMapPathStyle+"|enc:"+opts.Polyline)
+	}
+	if opts.Origin != nil {
+		query.Add("markers", "color:green|label:A|"+formatLatLng(*opts.Origin))
+	}
+	for i, waypoint := range opts.Waypoints {
+		style := "color:blue|size:mid|"
+		if i < 9 {
+			style += "label:" + strconv.Itoa(i+1) + "|"
+		}
+		query.Add("markers", style+formatLatLng(waypoint))
+	}
+	if opts.Destination != nil {
+		query.Add("markers", "color:red|label:B|"+formatLatLng(*opts.Destination))
+	}
+	if opts.SignWithKey && opts.APIKey != "" {
+		query.Set("key", opts.APIKey)
+	}
+	return base + "?" + query.Encode()
+}
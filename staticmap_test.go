@@ -0,0 +1,54 @@
+package goplaces
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestStaticMapURL(t *testing.T) {
+	route := DirectionsResponse{
+		EncodedPolyline: "_p~iF~ps|U_ulLnnqC_mqNvxq`@",
+		Legs: []DirectionsLeg{
+			{StartLocation: &LatLng{Lat: 38.5, Lng: -120.2}, EndLocation: &LatLng{Lat: 40.7, Lng: -120.95}},
+			{StartLocation: &LatLng{Lat: 40.7, Lng: -120.95}, EndLocation: &LatLng{Lat: 43.252, Lng: -126.453}},
+		},
+	}
+	opts := route.StaticMapOptions()
+	opts.APIKey = "secret"
+	raw := StaticMapURL(opts)
+	if !strings.HasPrefix(raw, DefaultStaticMapBaseURL+"?") {
+		t.Fatalf("unexpected base: %s", raw)
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	query := parsed.Query()
+	if query.Get("size") != "640x640" || query.Has("scale") {
+		t.Fatalf("unexpected size/scale: %v", query)
+	}
+	if query.Get("path") != "weight:5|color:0x4285F4ff|enc:_p~iF~ps|U_ulLnnqC_mqNvxq`@" {
+		t.Fatalf("unexpected path: %q", query.Get("path"))
+	}
+	markers := query["markers"]
+	if len(markers) != 3 ||
+		markers[0] != "color:green|label:A|38.500000,-120.200000" ||
+		markers[1] != "color:blue|size:mid|label:1|40.700000,-120.950000" ||
+		markers[2] != "color:red|label:B|43.252000,-126.453000" {
+		t.Fatalf("unexpected markers: %q", markers)
+	}
+	if query.Has("key") {
+		t.Fatalf("key must be opt-in: %s", raw)
+	}
+
+	opts.SignWithKey = true
+	opts.Width, opts.Height, opts.Scale = 400, 300, 2
+	query, err = url.ParseQuery(strings.SplitN(StaticMapURL(opts), "?", 2)[1])
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if query.Get("key") != "secret" || query.Get("size") != "400x300" || query.Get("scale") != "2" {
+		t.Fatalf("unexpected query: %v", query)
+	}
+}
@@ -9,6 +9,8 @@ type SearchRequest struct {
 	PageToken    string        `json:"page_token,omitempty"`
 	Language     string        `json:"language,omitempty"`
 	Region       string        `json:"region,omitempty"`
+	// IncludeEVChargeOptions requests live EV connector availability.
+	IncludeEVChargeOptions bool `json:"include_ev_charge_options,omitempty"`
 }
 
 // Filters are optional search refinements.
@@ -33,6 +35,12 @@ type LatLng struct {
 	Lng float64 `json:"lng"`
 }
 
+// LatLngBounds is a rectangle given by its northeast and southwest corners.
+type LatLngBounds struct {
+	Northeast LatLng `json:"northeast"`
+	Southwest LatLng `json:"southwest"`
+}
+
 // SearchResponse contains a list of places and optional pagination token.
 type SearchResponse struct {
 	Results       []PlaceSummary `json:"results"`
@@ -72,8 +80,11 @@ type NearbySearchRequest struct {
 	Limit               int           `json:"limit,omitempty"`
 	IncludedTypes       []string      `json:"included_types,omitempty"`
 	ExcludedTypes       []string      `json:"excluded_types,omitempty"`
+	PageToken           string        `json:"page_token,omitempty"`
 	Language            string        `json:"language,omitempty"`
 	Region              string        `json:"region,omitempty"`
+	// IncludeEVChargeOptions requests live EV connector availability.
+	IncludeEVChargeOptions bool `json:"include_ev_charge_options,omitempty"`
 }
 
 // NearbySearchResponse contains nearby search results.
@@ -84,31 +95,85 @@ type NearbySearchResponse struct {
 
 // PlaceSummary is a compact view of a place.
 type PlaceSummary struct {
-	PlaceID    string   `json:"place_id"`
-	Name       string   `json:"name,omitempty"`
-	Address    string   `json:"address,omitempty"`
-	Location   *LatLng  `json:"location,omitempty"`
-	Rating     *float64 `json:"rating,omitempty"`
-	PriceLevel *int     `json:"price_level,omitempty"`
-	Types      []string `json:"types,omitempty"`
-	OpenNow    *bool    `json:"open_now,omitempty"`
+	PlaceID         string   `json:"place_id"`
+	Name            string   `json:"name,omitempty"`
+	Address         string   `json:"address,omitempty"`
+	Location        *LatLng  `json:"location,omitempty"`
+	Rating          *float64 `json:"rating,omitempty"`
+	UserRatingCount *int     `json:"user_rating_count,omitempty"`
+	PriceLevel      *int     `json:"price_level,omitempty"`
+	Types           []string `json:"types,omitempty"`
+	OpenNow         *bool    `json:"open_now,omitempty"`
+	// PrimaryType is the main type (e.g. "cafe"); PrimaryTypeName is its localized label.
+	PrimaryType     string `json:"primary_type,omitempty"`
+	PrimaryTypeName string `json:"primary_type_name,omitempty"`
+	// EVChargeOptions is set only when IncludeEVChargeOptions was requested.
+	EVChargeOptions *EVChargeOptions `json:"ev_charge_options,omitempty"`
+	// PriceRange is the typical spend per person; nil when Google has none.
+	PriceRange *PriceRange `json:"price_range,omitempty"`
+}
+
+// PriceRange is a price band in one currency. EndAmount is nil for open-ended
+// ranges such as "100+".
+type PriceRange struct {
+	Currency    string   `json:"currency"`
+	StartAmount *float64 `json:"start_amount,omitempty"`
+	EndAmount   *float64 `json:"end_amount,omitempty"`
+}
+
+// EVChargeOptions summarizes the EV chargers at a place.
+type EVChargeOptions struct {
+	ConnectorCount int           `json:"connector_count,omitempty"`
+	Connectors     []EVConnector `json:"connectors,omitempty"`
+}
+
+// EVConnector aggregates connectors of one type and charge rate.
+// AvailableCount and OutOfServiceCount are nil when Google has no live data.
+type EVConnector struct {
+	Type                   string  `json:"type,omitempty"`
+	MaxChargeRateKW        float64 `json:"max_charge_rate_kw,omitempty"`
+	Count                  int     `json:"count,omitempty"`
+	AvailableCount         *int    `json:"available_count,omitempty"`
+	OutOfServiceCount      *int    `json:"out_of_service_count,omitempty"`
+	AvailabilityLastUpdate string  `json:"availability_last_update,omitempty"`
+}
+
+// AvailableConnectors sums live availability. known is false when no
+// connector reports availability, so callers can tell "none free" from "unknown".
+func (o *EVChargeOptions) AvailableConnectors() (available int, known bool) {
+	if o == nil {
+		return 0, false
+	}
+	for _, connector := range o.Connectors {
+		if connector.AvailableCount == nil {
+			continue
+		}
+		known = true
+		available += *connector.AvailableCount
+	}
+	return available, known
 }
 
 // PlaceDetails is a detailed view of a place.
 type PlaceDetails struct {
-	PlaceID    string   `json:"place_id"`
-	Name       string   `json:"name,omitempty"`
-	Address    string   `json:"address,omitempty"`
-	Location   *LatLng  `json:"location,omitempty"`
-	Rating     *float64 `json:"rating,omitempty"`
-	PriceLevel *int     `json:"price_level,omitempty"`
-	Types      []string `json:"types,omitempty"`
-	Phone      string   `json:"phone,omitempty"`
-	Website    string   `json:"website,omitempty"`
-	Hours      []string `json:"hours,omitempty"`
-	OpenNow    *bool    `json:"open_now,omitempty"`
-	Reviews    []Review `json:"reviews,omitempty"`
-	Photos     []Photo  `json:"photos,omitempty"`
+	PlaceID         string   `json:"place_id"`
+	Name            string   `json:"name,omitempty"`
+	Address         string   `json:"address,omitempty"`
+	Location        *LatLng  `json:"location,omitempty"`
+	Rating          *float64 `json:"rating,omitempty"`
+	UserRatingCount *int     `json:"user_rating_count,omitempty"`
+	PriceLevel      *int     `json:"price_level,omitempty"`
+	Types           []string `json:"types,omitempty"`
+	Phone           string   `json:"phone,omitempty"`
+	Website         string   `json:"website,omitempty"`
+	Hours           []string `json:"hours,omitempty"`
+	OpenNow         *bool    `json:"open_now,omitempty"`
+	Reviews         []Review `json:"reviews,omitempty"`
+	Photos          []Photo  `json:"photos,omitempty"`
+	// EditorialSummary is Google's one-line description; nil for most places.
+	EditorialSummary *LocalizedText `json:"editorial_summary,omitempty"`
+	// PriceRange is the typical spend per person; nil when Google has none.
+	PriceRange *PriceRange `json:"price_range,omitempty"`
 }
 
 // LocationResolveRequest resolves a text location into place candidates.
@@ -128,6 +193,10 @@ type DetailsRequest struct {
 	IncludeReviews bool `json:"include_reviews,omitempty"`
 	// IncludePhotos requests the photos field in Place Details.
 	IncludePhotos bool `json:"include_photos,omitempty"`
+	// IncludeEditorialSummary requests Google's one-line description.
+	IncludeEditorialSummary bool `json:"include_editorial_summary,omitempty"`
+	// SessionToken ends an autocomplete session so it is billed as one unit.
+	SessionToken string `json:"session_token,omitempty"`
 }
 
 // Review represents a user review of a place.
@@ -0,0 +1,96 @@
+package goplaces
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Location check statuses reported by ValidateLocations.
+const (
+	LocationOK        = "OK"
+	LocationAmbiguous = "AMBIGUOUS"
+	LocationNotFound  = "NOT_FOUND"
+	LocationInvalid   = "INVALID"
+	LocationError     = "ERROR"
+)
+
+var coordinatePattern = regexp.MustCompile(`^\s*(-?\d+(?:\.\d+)?)\s*,\s*(-?\d+(?:\.\d+)?)\s*$`)
+
+// LocationCheck is the verdict for one ValidateLocations input.
+type LocationCheck struct {
+	Input    string  `json:"input"`
+	Status   string  `json:"status"`
+	Address  string  `json:"address,omitempty"`
+	PlaceID  string  `json:"place_id,omitempty"`
+	Location *LatLng `json:"location,omitempty"`
+	// Candidates is how many geocoder results matched the input.
+	Candidates int    `json:"candidates,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	Err        error  `json:"-"`
+}
+
+// ValidateLocations geocodes addresses and reverse-geocodes "lat,lng" inputs
+// (Geocoding API), reporting whether each resolves to exactly one place.
+// Results are in input order. Only request failures (status ERROR) count as
+// errors for opts.StopOnError; not-found and ambiguous inputs never stop the batch.
+func (c *Client) ValidateLocations(ctx context.Context, inputs []string, opts BatchOptions) ([]LocationCheck, error) {
+	checks := make([]LocationCheck, len(inputs))
+	err := runBatch(ctx, len(inputs), opts, func(ctx context.Context, i int) error {
+		checks[i] = c.validateLocation(ctx, inputs[i])
+		return checks[i].Err
+	})
+	return checks, err
+}
+
+func (c *Client) validateLocation(ctx context.Context, input string) LocationCheck {
+	check := LocationCheck{Input: input}
+	text := strings.TrimSpace(input)
+	query := map[string]string{"address": text}
+	if match := coordinatePattern.FindStringSubmatch(text); match != nil {
+		lat, _ := strconv.ParseFloat(match[1], 64)
+		lng, _ := strconv.ParseFloat(match[2], 64)
+		if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+			check.Status = LocationInvalid
+			check.Reason = "coordinates out of range"
+			return check
+		}
+		query = map[string]string{"latlng": formatLatLng(LatLng{Lat: lat, Lng: lng})}
+	} else if text == "" {
+		check.Status = LocationInvalid
+		check.Reason = "empty input"
+		return check
+	}
+
+	results, err := c.geocode(ctx, query)
+	if err != nil {
+		check.Status = LocationError
+		check.Reason = err.Error()
+		check.Err = err
+		return check
+	}
+	if len(results) == 0 {
+		check.Status = LocationNotFound
+		return check
+	}
+
+	best := results[0]
+	check.Address = best.FormattedAddress
+	check.PlaceID = best.PlaceID
+	check.Location = &LatLng{Lat: best.Geometry.Location.Lat, Lng: best.Geometry.Location.Lng}
+	check.Candidates = len(results)
+	check.Status = LocationOK
+	switch {
+	case query["latlng"] != "":
+		// Reverse geocoding always lists several granularities; the first is the closest.
+	case len(results) > 1:
+		check.Status = LocationAmbiguous
+		check.Reason = fmt.Sprintf("%d candidates", len(results))
+	case best.PartialMatch:
+		check.Status = LocationAmbiguous
+		check.Reason = "partial match"
+	}
+	return check
+}
@@ -0,0 +1,85 @@
+package goplaces
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func newGeocodeValidateServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case query.Get("latlng") == "47.600000,-122.300000":
+			_, _ = w.Write([]byte(`{"status": "OK", "results": [
+				{"formatted_address": "Pike St, Seattle", "place_id": "p1", "geometry": {"location": {"lat": 47.6, "lng": -122.3}}},
+				{"formatted_address": "Seattle, WA", "place_id": "p2"}
+			]}`))
+		case query.Get("address") == "1 Main St":
+			_, _ = w.Write([]byte(`{"status": "OK", "results": [{"formatted_address": "1 Main St, Town", "place_id": "m1", "geometry": {"location": {"lat": 1, "lng": 2}}}]}`))
+		case query.Get("address") == "Springfield":
+			_, _ = w.Write([]byte(`{"status": "OK", "results": [{"formatted_address": "Springfield, IL"}, {"formatted_address": "Springfield, MA"}]}`))
+		case query.Get("address") == "Main Stret":
+			_, _ = w.Write([]byte(`{"status": "OK", "results": [{"formatted_address": "Main St", "partial_match": true}]}`))
+		case query.Get("address") == "nowhere":
+			_, _ = w.Write([]byte(`{"status": "ZERO_RESULTS", "results": []}`))
+		default:
+			_, _ = w.Write([]byte(`{"status": "UNKNOWN_ERROR", "error_message": "boom"}`))
+		}
+	}))
+}
+
+func TestValidateLocations(t *testing.T) {
+	server := newGeocodeValidateServer(t)
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", GeocodeBaseURL: server.URL})
+	inputs := []string{"1 Main St", "Springfield", "Main Stret", "nowhere", "47.6, -122.3", "91,0", "explode"}
+	var progress atomic.Int32
+	checks, err := client.ValidateLocations(context.Background(), inputs, BatchOptions{
+		Progress: func(done, total int) {
+			if total != len(inputs) {
+				t.Errorf("unexpected total: %d", total)
+			}
+			progress.Add(1)
+		},
+	})
+	if err != nil {
+		t.Fatalf("ValidateLocations error: %v", err)
+	}
+	want := []string{LocationOK, LocationAmbiguous, LocationAmbiguous, LocationNotFound, LocationOK, LocationInvalid, LocationError}
+	for i, check := range checks {
+		if check.Status != want[i] {
+			t.Fatalf("%q: expected %s, got %#v", inputs[i], want[i], check)
+		}
+	}
+	if checks[0].PlaceID != "m1" || checks[0].Location == nil || checks[0].Location.Lng != 2 {
+		t.Fatalf("unexpected resolved location: %#v", checks[0])
+	}
+	if checks[1].Reason != "2 candidates" || checks[2].Reason != "partial match" {
+		t.Fatalf("unexpected ambiguity reasons: %q / %q", checks[1].Reason, checks[2].Reason)
+	}
+	if checks[4].Address != "Pike St, Seattle" {
+		t.Fatalf("expected closest reverse geocode result, got %#v", checks[4])
+	}
+	if checks[6].Err == nil {
+		t.Fatalf("expected request error to be kept: %#v", checks[6])
+	}
+	if progress.Load() != int32(len(inputs)) {
+		t.Fatalf("expected progress per item, got %d", progress.Load())
+	}
+}
+
+func TestValidateLocationsStopOnError(t *testing.T) {
+	server := newGeocodeValidateServer(t)
+	defer server.Close()
+
+	client := NewClient(Options{APIKey: "test-key", GeocodeBaseURL: server.URL})
+	_, err := client.ValidateLocations(context.Background(), []string{"nowhere", "explode", "1 Main St"}, BatchOptions{StopOnError: true, Concurrency: 1})
+	if err == nil {
+		t.Fatalf("expected request error to stop the batch")
+	}
+}
@@ -0,0 +1,7 @@
+package goplaces
+
+// Version is the library release, sent in the default User-Agent.
+const Version = "0.2.2"
+
+// DefaultUserAgent is sent when Options.UserAgent is empty.
+const DefaultUserAgent = "goplaces/" + Version